@@ -1,12 +1,21 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net/url"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
 	"time"
+	"unicode"
 
 	"github.com/7c/coadmin-golib/issues"
 	"github.com/fatih/color"
@@ -21,15 +30,24 @@ var (
 	server      string
 	debug       bool
 	wait        time.Duration
+	metaFlags   []string
+	tagFlags    []string
+	extraJSON   string
+	optionJSON  string
+
+	folder          string
+	deleteOnSuccess bool
+	concurrency     int
+	jsonOutput      bool
 )
 
-var validLevels = []string{"warning", "error", "info", "debug", "fatal"}
 var logDebug = log.New(os.Stdout, color.New(color.FgCyan).Sprint("[DEBUG] "), 0)
 
 func main() {
 	rootCmd := &cobra.Command{
-		Use:   "coadmin-cli",
-		Short: "Coadmin CLI tool",
+		Use:     "coadmin-cli",
+		Short:   "Coadmin CLI tool",
+		Version: issues.Version(),
 	}
 
 	// 'issue' command
@@ -47,19 +65,48 @@ func main() {
 
 	// Setup flags for 'issue submit'
 	submitCmd.Flags().StringVar(&app, "app", "", "Application name (min 3 characters)")
-	submitCmd.Flags().StringVar(&description, "description", "", "Issue description (min 3 characters)")
-	submitCmd.Flags().StringVar(&level, "level", "", "Issue level (warning|error|info|debug|fatal)")
+	submitCmd.Flags().StringVar(&description, "description", "", "Issue description (min 3 characters), - or omitted to read it from stdin")
+	submitCmd.Flags().StringVar(&level, "level", "", "Issue level ("+strings.Join(levelNames(), "|")+")")
 	submitCmd.Flags().BoolVar(&live, "live", false, "Enable live mode")
 	submitCmd.Flags().StringVar(&server, "server", "", "Server URL (required if live mode is enabled)")
 	submitCmd.Flags().DurationVar(&wait, "wait", 10*time.Second, "Wait for the issue to be submitted (max 10 seconds)")
 	submitCmd.Flags().BoolVar(&debug, "debug", false, "Enable debug mode")
+	submitCmd.Flags().StringArrayVar(&metaFlags, "meta", nil, "Meta data as key=value, may be repeated")
+	submitCmd.Flags().StringArrayVar(&tagFlags, "tag", nil, "Tag of the issue, may be repeated")
+	submitCmd.Flags().StringVar(&extraJSON, "extra", "", "Extra data as a JSON object")
+	submitCmd.Flags().StringVar(&optionJSON, "option", "", "Report options as a JSON object")
 
 	// Mark required flags.
 	submitCmd.MarkFlagRequired("app")
-	submitCmd.MarkFlagRequired("description")
 	submitCmd.MarkFlagRequired("level")
 
+	// 'flush' subcommand under 'issue'
+	flushCmd := &cobra.Command{
+		Use:   "flush",
+		Short: "Submit the issue files stored in a folder to a server",
+		Run:   runFlush,
+	}
+	flushCmd.Flags().StringVar(&folder, "folder", "/var/coadmin", "Folder holding the issue files")
+	flushCmd.Flags().StringVar(&server, "server", "", "Server URL")
+	flushCmd.Flags().BoolVar(&deleteOnSuccess, "delete-on-success", true, "Delete the files submitted successfully")
+	flushCmd.Flags().IntVar(&concurrency, "concurrency", 4, "Number of files submitted in parallel")
+	flushCmd.Flags().DurationVar(&wait, "timeout", 10*time.Second, "Timeout of each submission")
+	flushCmd.Flags().BoolVar(&debug, "debug", false, "Enable debug mode")
+	flushCmd.MarkFlagRequired("server")
+
+	// 'list' subcommand under 'issue'
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the issue files stored in a folder",
+		Run:   runList,
+	}
+	listCmd.Flags().StringVar(&folder, "folder", "/var/coadmin", "Folder holding the issue files")
+	listCmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the reports as a JSON array")
+	listCmd.Flags().StringVar(&level, "level", "", "Only list issues of this level ("+strings.Join(levelNames(), "|")+")")
+
 	issueCmd.AddCommand(submitCmd)
+	issueCmd.AddCommand(flushCmd)
+	issueCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(issueCmd)
 
 	if err := rootCmd.Execute(); err != nil {
@@ -76,15 +123,43 @@ func runSubmit(cmd *cobra.Command, args []string) {
 		errMessages = append(errMessages, "--app must be at least 3 characters")
 	}
 
-	// Validate --description
+	// Validate --description, read from stdin when - or omitted.
+	if description == "-" || (description == "" && !stdinIsTerminal()) {
+		text, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			errMessages = append(errMessages, fmt.Sprintf("failed to read the description from stdin: %v", err))
+		}
+		description = strings.TrimRightFunc(string(text), unicode.IsSpace)
+	}
 	if len(description) < 3 {
 		errMessages = append(errMessages, "--description must be at least 3 characters")
 	}
 
 	// Validate --level
-	lowerLevel := strings.ToLower(level)
-	if !contains(validLevels, lowerLevel) {
-		errMessages = append(errMessages, fmt.Sprintf("--level must be one of: %s", strings.Join(validLevels, ", ")))
+	parsedLevel, err := issues.ParseLevel(level)
+	if err != nil {
+		errMessages = append(errMessages, fmt.Sprintf("--level must be one of: %s", strings.Join(levelNames(), ", ")))
+	}
+
+	// Validate --meta
+	meta := make(map[string]string, len(metaFlags))
+	for _, entry := range metaFlags {
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok || key == "" {
+			errMessages = append(errMessages, fmt.Sprintf("--meta %q must be in the key=value form", entry))
+			continue
+		}
+		meta[key] = value
+	}
+
+	// Validate --extra and --option
+	extra, err := parseJSONObject(extraJSON)
+	if err != nil {
+		errMessages = append(errMessages, fmt.Sprintf("--extra must be a JSON object: %v", err))
+	}
+	repOptions, err := parseJSONObject(optionJSON)
+	if err != nil {
+		errMessages = append(errMessages, fmt.Sprintf("--option must be a JSON object: %v", err))
 	}
 
 	// Validate live mode options if --live is set
@@ -112,33 +187,48 @@ func runSubmit(cmd *cobra.Command, args []string) {
 	fmt.Println("Submitting issue with parameters:")
 	fmt.Printf("App: %s\n", app)
 	fmt.Printf("Description: %s\n", description)
-	fmt.Printf("Level: %s\n", lowerLevel)
+	fmt.Printf("Level: %s\n", parsedLevel)
+	for _, entry := range metaFlags {
+		fmt.Printf("Meta: %s\n", entry)
+	}
+	for _, tag := range tagFlags {
+		fmt.Printf("Tag: %s\n", tag)
+	}
 	if live {
 		fmt.Println("Live mode enabled")
 		fmt.Printf("Server: %s\n", server)
 	}
 
 	// Initialize ReportIssues with appropriate options.
-	opts := issues.Options{
-		Live:            live,
-		Server:          server,
-		MinimumInterval: 60 * time.Second,
-		Folder:          "/var/coadmin",
-		Output:          false,
-		Debug:           debug,
-	}
-	ri := issues.NewReportIssues(app, &opts)
+	ri := issues.NewReportIssues(app,
+		issues.WithLive(live),
+		issues.WithServer(server),
+		issues.WithMinimumInterval(60*time.Second),
+		issues.WithFolder("/var/coadmin"),
+		issues.WithOutput(false),
+		issues.WithDebug(debug),
+		issues.WithDefaultTags(tagFlags...),
+	)
+	ri.SetMetaMap(meta)
 
-	extra := make(map[string]interface{})
-	repOptions := make(map[string]interface{})
-	success := ri.Add(description, extra, lowerLevel, repOptions)
+	result := ri.AddResult(description, extra, parsedLevel, repOptions)
+	switch result.Reason {
+	case issues.ResultThrottled:
+		fmt.Printf("Issue was throttled, next allowed at %s\n", result.NextAllowed.Format(time.RFC3339))
+		os.Exit(1)
+	case issues.ResultError:
+		fmt.Printf("Issue submission failed: %v\n", result.Err)
+		os.Exit(1)
+	}
 
 	// In live mode, allow time for liveWorker to process the buffered report.
 	if live {
 		logDebug.Printf("Waiting for liveWorker to process the buffered report, max %s", wait)
-		submitted := ri.WaitQueue(wait)
-		if !submitted {
-			fmt.Println("Issue submission failed")
+		ctx, cancel := context.WithTimeout(context.Background(), wait)
+		err := ri.Flush(ctx)
+		cancel()
+		if err != nil {
+			fmt.Printf("Issue submission failed: %v\n", err)
 			os.Exit(1)
 		} else {
 			fmt.Println("Issue submitted successfully")
@@ -146,21 +236,146 @@ func runSubmit(cmd *cobra.Command, args []string) {
 		}
 
 	} else {
-		if success {
-			fmt.Println("Issue submitted successfully")
-			os.Exit(0)
-		} else {
-			fmt.Println("Issue submission failed")
+		fmt.Println("Issue submitted successfully")
+		os.Exit(0)
+	}
+}
+
+func runFlush(cmd *cobra.Command, args []string) {
+	if _, err := url.ParseRequestURI(server); err != nil {
+		fmt.Println("Error: --server must be a valid URL")
+		os.Exit(1)
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	files, err := filepath.Glob(filepath.Join(folder, "*"+issues.ReportExt))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	ri := issues.NewReportIssues("coadmin-cli",
+		issues.WithServer(server),
+		issues.WithRequestTimeout(wait),
+		issues.WithDebug(debug),
+	)
+	var sent, failed, skipped atomic.Int32
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range jobs {
+				report, err := issues.ReadReportFile(file)
+				if err != nil {
+					fmt.Printf("Skipping: %v\n", err)
+					skipped.Add(1)
+					continue
+				}
+				if err := ri.SubmitReport(context.Background(), report); err != nil {
+					fmt.Printf("Failed to submit %s: %v\n", file, err)
+					failed.Add(1)
+					continue
+				}
+				sent.Add(1)
+				if deleteOnSuccess {
+					if err := os.Remove(file); err != nil {
+						fmt.Printf("Failed to delete %s: %v\n", file, err)
+					}
+				}
+			}
+		}()
+	}
+	for _, file := range files {
+		jobs <- file
+	}
+	close(jobs)
+	wg.Wait()
+
+	fmt.Printf("Sent: %d, failed: %d, skipped: %d\n", sent.Load(), failed.Load(), skipped.Load())
+	if failed.Load() > 0 {
+		os.Exit(1)
+	}
+}
+
+func runList(cmd *cobra.Command, args []string) {
+	lowerLevel := strings.ToLower(level)
+	if lowerLevel != "" && !issues.Level(lowerLevel).IsValid() {
+		fmt.Printf("Error: --level must be one of: %s\n", strings.Join(levelNames(), ", "))
+		os.Exit(1)
+	}
+	reports, err := issues.LoadReports(folder)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	filtered := make([]issues.Report, 0, len(reports))
+	for _, report := range reports {
+		if lowerLevel == "" || report.Level == lowerLevel {
+			filtered = append(filtered, report)
+		}
+	}
+	// Most recent first.
+	slices.Reverse(filtered)
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(filtered, "", "  ")
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
 			os.Exit(1)
 		}
+		fmt.Println(string(data))
+		return
 	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ISSUE ID\tLEVEL\tAPP\tTIME\tDESCRIPTION")
+	for _, report := range filtered {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n",
+			report.IssueID, report.Level, report.App,
+			time.UnixMilli(report.T).Format(time.RFC3339), truncate(report.Description, 60))
+	}
+	w.Flush()
 }
 
-func contains(slice []string, item string) bool {
-	for _, s := range slice {
-		if s == item {
-			return true
-		}
+// truncate shortens s to at most n runes, marking the cut with an ellipsis.
+func truncate(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// levelNames returns the levels supported by the library.
+func levelNames() []string {
+	names := make([]string, len(issues.Levels))
+	for i, l := range issues.Levels {
+		names[i] = string(l)
+	}
+	return names
+}
+
+// stdinIsTerminal reports whether stdin is an interactive terminal rather
+// than a pipe or a file.
+func stdinIsTerminal() bool {
+	stat, err := os.Stdin.Stat()
+	if err != nil {
+		return true
+	}
+	return stat.Mode()&os.ModeCharDevice != 0
+}
+
+// parseJSONObject parses the JSON object of a flag, an empty map when the
+// flag is empty.
+func parseJSONObject(value string) (map[string]interface{}, error) {
+	object := make(map[string]interface{})
+	if value == "" {
+		return object, nil
+	}
+	if err := json.Unmarshal([]byte(value), &object); err != nil {
+		return nil, err
 	}
-	return false
+	return object, nil
 }
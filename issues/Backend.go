@@ -0,0 +1,101 @@
+package issues
+
+import (
+	"context"
+	"fmt"
+)
+
+// Backend delivers the reports of the live worker to the coadmin server,
+// see Options.Backend. The default backend POSTs them to Server or Servers
+// over HTTP, encoded with Options.Encoding.
+type Backend interface {
+	Submit(ctx context.Context, submission ReportSubmission) error
+}
+
+// BulkBackend is implemented by the backends able to deliver a batch of
+// reports at once. With a BatchSize above one, batches are otherwise
+// delivered one report at a time.
+type BulkBackend interface {
+	Backend
+	SubmitBulk(ctx context.Context, submission BulkReportSubmission) error
+}
+
+// httpBackend is the default Backend, posting to the servers of ri.
+type httpBackend struct {
+	ri *ReportIssues
+}
+
+var _ BulkBackend = httpBackend{}
+
+// Submit posts the submission to the servers, see postFailover.
+func (b httpBackend) Submit(ctx context.Context, submission ReportSubmission) error {
+	_, err := b.send(ctx, submission, false)
+	return err
+}
+
+// SubmitBulk posts the submission to BulkServer when set, to the servers
+// otherwise.
+func (b httpBackend) SubmitBulk(ctx context.Context, submission BulkReportSubmission) error {
+	_, err := b.send(ctx, submission, true)
+	return err
+}
+
+// send implements Submit and SubmitBulk, returning the status code of the
+// response.
+func (b httpBackend) send(ctx context.Context, submission interface{}, bulk bool) (int, error) {
+	data, err := b.ri.marshalSubmission(submission)
+	if err != nil {
+		return 0, fmt.Errorf("marshalling submission: %w", err)
+	}
+	if bulk && b.ri.Options.BulkServer != "" {
+		return b.ri.post(ctx, b.ri.Options.BulkServer, data)
+	}
+	return b.ri.postFailover(ctx, data)
+}
+
+// backend returns Options.Backend, or the HTTP backend when nil.
+func (ri *ReportIssues) backend() Backend {
+	if ri.Options.Backend != nil {
+		return ri.Options.Backend
+	}
+	return httpBackend{ri: ri}
+}
+
+// deliver sends batch through the backend. It returns the number of reports
+// delivered, the leading ones of batch since a batch sent one report at a
+// time stops at the first failure, and the HTTP status code of the last
+// response, zero with a custom Backend.
+func (ri *ReportIssues) deliver(ctx context.Context, batch []Report) (int, int, error) {
+	backend := ri.backend()
+	http, isHTTP := backend.(httpBackend)
+	if ri.batchSize() > 1 {
+		var status int
+		var err error
+		bulk, isBulk := backend.(BulkBackend)
+		switch {
+		case isHTTP:
+			status, err = http.send(ctx, BulkReportSubmission{Issues: batch}, true)
+		case isBulk:
+			err = bulk.SubmitBulk(ctx, BulkReportSubmission{Issues: batch})
+		}
+		if isHTTP || isBulk {
+			if err != nil {
+				return 0, status, err
+			}
+			return len(batch), status, nil
+		}
+	}
+	status := 0
+	for i, report := range batch {
+		var err error
+		if isHTTP {
+			status, err = http.send(ctx, ReportSubmission{Issue: report}, false)
+		} else {
+			err = backend.Submit(ctx, ReportSubmission{Issue: report})
+		}
+		if err != nil {
+			return i, status, err
+		}
+	}
+	return len(batch), status, nil
+}
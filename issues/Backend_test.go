@@ -0,0 +1,60 @@
+package issues
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flakyBackend is a Backend without SubmitBulk failing the first attempt of
+// the issue failOn.
+type flakyBackend struct {
+	failOn string
+
+	mu       sync.Mutex
+	attempts map[string]int
+}
+
+func (b *flakyBackend) Submit(ctx context.Context, submission ReportSubmission) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	description := submission.Issue.Description
+	b.attempts[description]++
+	if description == b.failOn && b.attempts[description] == 1 {
+		return errors.New("connection reset")
+	}
+	return nil
+}
+
+func TestDeliverRetriesOnlyUnsent(t *testing.T) {
+	backend := &flakyBackend{failOn: "second", attempts: make(map[string]int)}
+	ri := NewReportIssues("test",
+		WithLive(true),
+		WithBackend(backend),
+		WithBatchSize(3),
+		WithRetryBaseInterval(10*time.Millisecond),
+	)
+	defer ri.Shutdown(context.Background())
+	for _, issue := range []string{"first", "second", "third"} {
+		ri.Add(issue, nil, LevelError, nil)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := ri.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	want := map[string]int{"first": 1, "second": 2, "third": 1}
+	for issue, n := range want {
+		if backend.attempts[issue] != n {
+			t.Errorf("%s sent %d times, want %d", issue, backend.attempts[issue], n)
+		}
+	}
+	if stats := ri.GetStats(); stats.TotalSubmitted != 3 || stats.TotalFailed != 2 {
+		t.Errorf("submitted %d, failed %d, want 3 and 2", stats.TotalSubmitted, stats.TotalFailed)
+	}
+}
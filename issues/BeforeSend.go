@@ -0,0 +1,32 @@
+package issues
+
+import "errors"
+
+// ErrDropped is returned by AddE when Options.BeforeSend dropped the report.
+var ErrDropped = errors.New("report dropped by BeforeSend")
+
+// beforeSend applies Options.BeforeSend to report. A panicking hook is
+// recovered and the report is kept unchanged.
+func (ri *ReportIssues) beforeSend(report *Report) (result *Report) {
+	if ri.Options.BeforeSend == nil {
+		return report
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			ri.LogDebug("BeforeSend panicked, keeping report %d: %v", report.IssueID, r)
+			result = report
+		}
+	}()
+	return ri.Options.BeforeSend(report)
+}
+
+// dropped tells whether BeforeSend dropped the report whose throttle key is
+// given, forgetting it then so its next occurrence is not throttled.
+func (ri *ReportIssues) dropped(report *Report, key throttleKey) bool {
+	if report != nil {
+		return false
+	}
+	ri.unthrottle(key)
+	ri.LogDebug("Report %d dropped by BeforeSend", key.hash)
+	return true
+}
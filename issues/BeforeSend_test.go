@@ -0,0 +1,45 @@
+package issues
+
+import "testing"
+
+func TestBeforeSendDropUnthrottlesAddReport(t *testing.T) {
+	drop := true
+	calls := 0
+	ri := NewReportIssues("test",
+		WithFolder(t.TempDir()),
+		WithBeforeSend(func(r *Report) *Report {
+			calls++
+			if drop {
+				return nil
+			}
+			return r
+		}),
+	)
+	report := Report{IssueID: 42, Description: "dropped once", Level: "error"}
+	ri.AddReport(report)
+	drop = false
+	ri.AddReport(report)
+	if calls != 2 {
+		t.Fatalf("BeforeSend called %d times, want 2: the dropped report stayed throttled", calls)
+	}
+}
+
+func TestBeforeSendDropUnthrottlesAdd(t *testing.T) {
+	drop := true
+	ri := NewReportIssues("test",
+		WithFolder(t.TempDir()),
+		WithBeforeSend(func(r *Report) *Report {
+			if drop {
+				return nil
+			}
+			return r
+		}),
+	)
+	if result := ri.AddResult("dropped once", nil, LevelError, nil); result.Reason != ResultDropped {
+		t.Fatalf("first Add: got %s, want %s", result.Reason, ResultDropped)
+	}
+	drop = false
+	if result := ri.AddResult("dropped once", nil, LevelError, nil); result.Reason != ResultWrittenToFile {
+		t.Fatalf("second Add: got %s, want %s", result.Reason, ResultWrittenToFile)
+	}
+}
@@ -0,0 +1,123 @@
+package issues
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Strategies for Options.BufferOverflow.
+const (
+	// BufferOverflowDropOldest evicts the oldest buffered report.
+	BufferOverflowDropOldest = "drop_oldest"
+	// BufferOverflowDropNewest discards the incoming report.
+	BufferOverflowDropNewest = "drop_newest"
+	// BufferOverflowBlock makes Add wait until space is available.
+	BufferOverflowBlock = "block"
+)
+
+// ErrBufferFull is returned when a report could not be buffered because the
+// live buffer is full.
+var ErrBufferFull = errors.New("live buffer full")
+
+// ErrClosed is returned by Flush when the live worker was shut down.
+var ErrClosed = errors.New("reporter closed")
+
+// ErrInvalidBufferOverflow is returned when Options.BufferOverflow is not
+// one of the BufferOverflow strategies.
+var ErrInvalidBufferOverflow = errors.New("invalid buffer overflow strategy")
+
+// ValidateBufferOverflow checks that strategy is BufferOverflowDropOldest,
+// BufferOverflowDropNewest or BufferOverflowBlock. An empty strategy stands
+// for the default one.
+func ValidateBufferOverflow(strategy string) error {
+	switch strategy {
+	case "", BufferOverflowDropOldest, BufferOverflowDropNewest, BufferOverflowBlock:
+		return nil
+	}
+	return fmt.Errorf("%w %q", ErrInvalidBufferOverflow, strategy)
+}
+
+// bufferSize returns the capacity of the live buffer channel.
+func bufferSize(opts Options) int {
+	if opts.BufferChanSize > 0 {
+		return opts.BufferChanSize
+	}
+	if opts.MaxBufferSize > 0 {
+		return opts.MaxBufferSize
+	}
+	return defaultOptions.MaxBufferSize
+}
+
+// BufferLen returns the number of reports waiting in the live buffer.
+func (ri *ReportIssues) BufferLen() int {
+	return len(ri.buffer)
+}
+
+// BufferCap returns the capacity of the live buffer.
+func (ri *ReportIssues) BufferCap() int {
+	return cap(ri.buffer)
+}
+
+// enqueue sends a report to the live buffer, applying the BufferOverflow
+// strategy when the buffer is full. Blocking stops once ctx is done.
+func (ri *ReportIssues) enqueue(ctx context.Context, report Report) error {
+	if ri.overflowErr != nil {
+		return ri.overflowErr
+	}
+	select {
+	case ri.buffer <- report:
+		return nil
+	default:
+	}
+
+	switch ri.Options.BufferOverflow {
+	case BufferOverflowDropNewest:
+		ri.logIssue("dropped", report.id(), report.Level, "Buffer full, discarding IssueID %d", report.IssueID)
+		ri.stats.dropped.Add(1)
+		ri.bufferFull(report)
+		return ErrBufferFull
+	case BufferOverflowBlock:
+		ri.LogDebug("Buffer full, waiting for space for IssueID %d", report.IssueID)
+		ri.bufferFull(report)
+		var timeout <-chan time.Time
+		if ri.Options.BufferBlockTimeout > 0 {
+			timer := time.NewTimer(ri.Options.BufferBlockTimeout)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+		select {
+		case ri.buffer <- report:
+			return nil
+		case <-timeout:
+			ri.logIssue("dropped", report.id(), report.Level, "Timed out waiting for buffer space for IssueID %d", report.IssueID)
+			ri.stats.dropped.Add(1)
+			return ErrBufferFull
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	default:
+		for {
+			select {
+			case ri.buffer <- report:
+				return nil
+			default:
+			}
+			select {
+			case evicted := <-ri.buffer:
+				ri.logIssue("evicted", evicted.id(), evicted.Level, "Buffer full, evicted IssueID %d", evicted.IssueID)
+				ri.stats.dropped.Add(1)
+				ri.bufferFull(evicted)
+			default:
+			}
+		}
+	}
+}
+
+// bufferFull reports an overflow event to the OnBufferFull callback.
+func (ri *ReportIssues) bufferFull(report Report) {
+	if ri.Options.OnBufferFull != nil {
+		ri.Options.OnBufferFull(report)
+	}
+}
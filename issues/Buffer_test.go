@@ -0,0 +1,93 @@
+package issues
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestBufferSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []func(*Options)
+		want    int
+	}{
+		{"default", nil, 10000},
+		{"max buffer size", []func(*Options){WithMaxBufferSize(50)}, 50},
+		{"deprecated chan size", []func(*Options){WithBufferChanSize(20)}, 20},
+		{"options struct", []func(*Options){WithOptions(&Options{Folder: "/tmp"})}, 10000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewReportIssues("test", tt.options...).BufferCap(); got != tt.want {
+				t.Errorf("BufferCap() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// drain empties the buffer of ri, returning the IssueIDs in order.
+func drain(ri *ReportIssues) []uint32 {
+	var ids []uint32
+	for len(ri.buffer) > 0 {
+		ids = append(ids, (<-ri.buffer).IssueID)
+	}
+	return ids
+}
+
+func TestBufferOverflow(t *testing.T) {
+	tests := []struct {
+		strategy string
+		want     []uint32
+		wantErr  error
+	}{
+		{BufferOverflowDropOldest, []uint32{2, 3, 4}, nil},
+		{BufferOverflowDropNewest, []uint32{1, 2, 3}, ErrBufferFull},
+		{BufferOverflowBlock, []uint32{1, 2, 3}, ErrBufferFull},
+	}
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			var overflowed []uint32
+			ri := NewReportIssues("test",
+				WithMaxBufferSize(3),
+				WithBufferOverflow(tt.strategy, 10*time.Millisecond),
+				WithOnBufferFull(func(r Report) { overflowed = append(overflowed, r.IssueID) }),
+			)
+			var err error
+			for id := uint32(1); id <= 4; id++ {
+				err = ri.enqueue(context.Background(), Report{IssueID: id})
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("enqueue() = %v, want %v", err, tt.wantErr)
+			}
+			if got := drain(ri); !slices.Equal(got, tt.want) {
+				t.Errorf("buffer = %v, want %v", got, tt.want)
+			}
+			if got := ri.GetStats().TotalDropped; got != 1 {
+				t.Errorf("Dropped = %d, want 1", got)
+			}
+			if len(overflowed) != 1 {
+				t.Errorf("OnBufferFull called %d times, want 1", len(overflowed))
+			}
+		})
+	}
+}
+
+func TestBufferOverflowInvalid(t *testing.T) {
+	if err := ValidateBufferOverflow("drop-oldest"); !errors.Is(err, ErrInvalidBufferOverflow) {
+		t.Fatalf("ValidateBufferOverflow(drop-oldest) = %v, want ErrInvalidBufferOverflow", err)
+	}
+	if err := ValidateBufferOverflow(""); err != nil {
+		t.Fatalf("ValidateBufferOverflow of the default: %v", err)
+	}
+
+	ri := NewReportIssues("test", WithBufferOverflow("drop-oldest", 0))
+	if err := ri.enqueue(context.Background(), Report{IssueID: 1}); !errors.Is(err, ErrInvalidBufferOverflow) {
+		t.Fatalf("enqueue() = %v, want ErrInvalidBufferOverflow", err)
+	}
+	if got := ri.BufferLen(); got != 0 {
+		t.Fatalf("BufferLen() = %d, want 0", got)
+	}
+}
@@ -0,0 +1,96 @@
+package issues
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// States of the circuit breaker, see GetCircuitState.
+const (
+	// CircuitClosed means submissions are sent normally.
+	CircuitClosed = "closed"
+	// CircuitOpen means the server failed repeatedly and submissions are
+	// suspended, reports stay buffered.
+	CircuitOpen = "open"
+	// CircuitHalfOpen means a probe submission is allowed to test whether
+	// the server recovered.
+	CircuitHalfOpen = "half_open"
+)
+
+// circuitBreaker is the state of the circuit breaker, protected by ri.Mutex.
+type circuitBreaker struct {
+	state    string
+	failures int       // consecutive failed submissions
+	openedAt time.Time // when the circuit last opened
+}
+
+// GetCircuitState returns CircuitClosed, CircuitOpen or CircuitHalfOpen.
+func (ri *ReportIssues) GetCircuitState() string {
+	ri.Mutex.Lock()
+	defer ri.Mutex.Unlock()
+	if ri.circuit.state == "" {
+		return CircuitClosed
+	}
+	return ri.circuit.state
+}
+
+// circuitAllow reports whether the live worker may submit at now, moving an
+// open circuit to half-open once CircuitBreakerTimeout has elapsed.
+func (ri *ReportIssues) circuitAllow(now time.Time) bool {
+	ri.Mutex.Lock()
+	if ri.circuit.state != CircuitOpen {
+		ri.Mutex.Unlock()
+		return true
+	}
+	if now.Before(ri.circuit.openedAt.Add(ri.Options.CircuitBreakerTimeout)) {
+		ri.Mutex.Unlock()
+		return false
+	}
+	ri.circuit.state = CircuitHalfOpen
+	ri.Mutex.Unlock()
+	ri.circuitChanged(CircuitHalfOpen)
+	return true
+}
+
+// circuitResult records the outcome of a submission. Failures caused by a
+// cancelled context do not count, and reports rejected by the server, see
+// Options.RetryableStatusCodes, count as a success.
+func (ri *ReportIssues) circuitResult(err error) {
+	if ri.Options.CircuitBreakerThreshold <= 0 || errors.Is(err, context.Canceled) {
+		return
+	}
+	if !ri.retryable(err) {
+		// The server is up, it only rejected the reports.
+		err = nil
+	}
+	ri.Mutex.Lock()
+	state := ri.circuit.state
+	if err == nil {
+		ri.circuit.failures = 0
+		ri.circuit.state = CircuitClosed
+	} else {
+		ri.circuit.failures++
+		if state == CircuitHalfOpen || ri.circuit.failures >= ri.Options.CircuitBreakerThreshold {
+			ri.circuit.state = CircuitOpen
+			ri.circuit.openedAt = ri.now()
+		}
+	}
+	changed := ri.circuit.state != state && !(state == "" && ri.circuit.state == CircuitClosed)
+	newState := ri.circuit.state
+	ri.Mutex.Unlock()
+	if changed {
+		ri.circuitChanged(newState)
+	}
+}
+
+// circuitChanged notifies the callbacks of a state transition.
+func (ri *ReportIssues) circuitChanged(state string) {
+	ri.LogDebug("Circuit breaker %s", state)
+	if ri.Options.OnCircuitStateChange != nil {
+		ri.Options.OnCircuitStateChange(state)
+	}
+	if state == CircuitOpen && ri.Options.OnCircuitOpen != nil {
+		ri.Options.OnCircuitOpen()
+	}
+}
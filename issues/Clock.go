@@ -0,0 +1,31 @@
+package issues
+
+import "time"
+
+// Clock tells the time used to throttle issues, timestamp reports and
+// schedule retries, see Options.Clock. Tests can inject a clock they advance
+// manually instead of sleeping.
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is the default Clock, backed by time.Now.
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+// now returns the current time of Options.Clock.
+func (ri *ReportIssues) now() time.Time {
+	if ri.Options.Clock == nil {
+		return time.Now()
+	}
+	return ri.Options.Clock.Now()
+}
+
+// pollInterval returns Options.PollInterval, or its default when unset.
+func (ri *ReportIssues) pollInterval() time.Duration {
+	if ri.Options.PollInterval <= 0 {
+		return defaultOptions.PollInterval
+	}
+	return ri.Options.PollInterval
+}
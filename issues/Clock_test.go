@@ -0,0 +1,62 @@
+package issues
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock advanced manually.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestThrottleWithFakeClock(t *testing.T) {
+	clock := newFakeClock()
+	ri := NewReportIssues("test",
+		WithFolder(t.TempDir()),
+		WithClock(clock),
+		WithMinimumInterval(time.Hour),
+	)
+	first, _ := ri.AddGet("disk full", nil, LevelError, nil)
+	if first == nil {
+		t.Fatal("the first occurrence was not reported")
+	}
+	if first.T != clock.Now().UnixMilli() {
+		t.Errorf("T = %d, want the time of the clock", first.T)
+	}
+
+	clock.Advance(time.Hour - time.Second)
+	if ri.Add("disk full", nil, LevelError, nil) {
+		t.Error("reported again before MinimumInterval elapsed")
+	}
+	if expiry, ok := ri.GetRateLimitExpiry(first.IssueID); !ok || !expiry.Equal(clock.Now().Add(time.Second)) {
+		t.Errorf("GetRateLimitExpiry() = %s, %v, want %s", expiry, ok, clock.Now().Add(time.Second))
+	}
+
+	clock.Advance(time.Second)
+	second, _ := ri.AddGet("disk full", nil, LevelError, nil)
+	if second == nil {
+		t.Fatal("still throttled once MinimumInterval elapsed")
+	}
+	if second.SuppressedCount != 1 {
+		t.Errorf("SuppressedCount = %d, want 1", second.SuppressedCount)
+	}
+}
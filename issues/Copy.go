@@ -0,0 +1,155 @@
+package issues
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MetaExtraSanitized is the meta key listing, comma separated, the paths of
+// the Extra values replaced because they could not be marshalled.
+const MetaExtraSanitized = "extra_sanitized"
+
+// maxCopyDepth caps the nesting of the maps and slices copied by copyMap.
+const maxCopyDepth = 32
+
+// setExtra replaces the Extra of r, given by the caller, by its sanitized
+// and redacted copy, see sanitizeMap and redact. The replaced values are
+// listed in the MetaExtraSanitized meta key.
+func (ri *ReportIssues) setExtra(r *Report) {
+	extra, replaced := sanitizeMap(r.Extra)
+	if len(replaced) > 0 {
+		sort.Strings(replaced)
+		r.Meta[MetaExtraSanitized] = strings.Join(replaced, ",")
+		ri.LogDebug("Report %d: replaced unmarshallable Extra values %v", r.IssueID, replaced)
+	}
+	r.Extra = ri.redact(extra)
+}
+
+// clone returns a deep copy of r, see copyMap.
+func (r Report) clone() Report {
+	r.Meta = maps.Clone(r.Meta)
+	r.Options = copyMap(r.Options)
+	r.Extra = copyMap(r.Extra)
+	r.StackTrace = slices.Clone(r.StackTrace)
+	r.Tags = slices.Clone(r.Tags)
+	return r
+}
+
+// copyMap returns a deep copy of m, so a report is not affected by later
+// changes to the maps passed to Add. Nested maps and slices of the JSON-like
+// types are copied, other values such as pointers are shared. See
+// sanitizeMap for the values replaced on the way.
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	c, _ := sanitizeMap(m)
+	return c
+}
+
+// sanitizeMap returns a deep copy of m like copyMap, in which the values
+// json.Marshal would fail on are replaced so the report always survives:
+// channels, funcs, NaN or infinite floats and other unsupported values by
+// their fmt %v representation, cycles by "[cycle]" and the containers
+// nested deeper than maxCopyDepth by "[max depth]". It also returns the
+// paths of the replaced values.
+func sanitizeMap(m map[string]interface{}) (map[string]interface{}, []string) {
+	if m == nil {
+		return nil, nil
+	}
+	s := sanitizer{seen: make(map[uintptr]bool)}
+	return s.copyMap(m, "", 0), s.replaced
+}
+
+// sanitizer implements sanitizeMap.
+type sanitizer struct {
+	seen     map[uintptr]bool // containers on the current path
+	replaced []string
+}
+
+func (s *sanitizer) copyMap(m map[string]interface{}, path string, depth int) map[string]interface{} {
+	c := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		keyPath := key
+		if path != "" {
+			keyPath = path + "." + key
+		}
+		c[key] = s.copyValue(value, keyPath, depth+1)
+	}
+	return c
+}
+
+// copyValue returns a deep copy of the JSON-like value v found at path.
+func (s *sanitizer) copyValue(v interface{}, path string, depth int) interface{} {
+	switch v := v.(type) {
+	case nil, string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, json.Number:
+		return v
+	case float64:
+		return s.float(v, math.IsNaN(v) || math.IsInf(v, 0), path)
+	case float32:
+		return s.float(v, math.IsNaN(float64(v)) || math.IsInf(float64(v), 0), path)
+	case map[string]interface{}:
+		if v == nil {
+			return v
+		}
+		return s.container(v, path, depth, func() interface{} {
+			return s.copyMap(v, path, depth)
+		})
+	case []interface{}:
+		if v == nil {
+			return v
+		}
+		return s.container(v, path, depth, func() interface{} {
+			c := make([]interface{}, len(v))
+			for i, value := range v {
+				c[i] = s.copyValue(value, path+"["+strconv.Itoa(i)+"]", depth+1)
+			}
+			return c
+		})
+	case map[string]string:
+		c := make(map[string]string, len(v))
+		for key, value := range v {
+			c[key] = value
+		}
+		return c
+	case []string:
+		return append([]string(nil), v...)
+	}
+	if _, err := json.Marshal(v); err != nil {
+		return s.replace(path, fmt.Sprintf("%v", v))
+	}
+	return v
+}
+
+// container copies the map or slice v with copy, unless it is already being
+// copied higher on the path or is nested too deep.
+func (s *sanitizer) container(v interface{}, path string, depth int, copy func() interface{}) interface{} {
+	if depth > maxCopyDepth {
+		return s.replace(path, "[max depth]")
+	}
+	ptr := reflect.ValueOf(v).Pointer()
+	if s.seen[ptr] {
+		return s.replace(path, "[cycle]")
+	}
+	s.seen[ptr] = true
+	defer delete(s.seen, ptr)
+	return copy()
+}
+
+// float returns f, or its string representation when invalid is set.
+func (s *sanitizer) float(f interface{}, invalid bool, path string) interface{} {
+	if invalid {
+		return s.replace(path, fmt.Sprintf("%v", f))
+	}
+	return f
+}
+
+// replace records path as replaced by value.
+func (s *sanitizer) replace(path string, value interface{}) interface{} {
+	s.replaced = append(s.replaced, path)
+	return value
+}
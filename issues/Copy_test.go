@@ -0,0 +1,129 @@
+package issues
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestExtraCopiedForLiveWorker(t *testing.T) {
+	received := make(chan Report, 1)
+	ri := newLiveReporter(t, func(w http.ResponseWriter, r *http.Request) {
+		var submission ReportSubmission
+		if err := json.NewDecoder(r.Body).Decode(&submission); err != nil {
+			t.Errorf("decoding the submission: %v", err)
+		}
+		received <- submission.Issue
+	})
+	nested := map[string]interface{}{"host": "db1"}
+	list := []interface{}{"a", "b"}
+	extra := map[string]interface{}{"count": 0, "nested": nested, "list": list}
+	ri.Add("mutated extra", extra, LevelError, nil)
+
+	// Mutate the maps while the live worker marshals the report.
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 1; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			extra["count"] = i
+			extra["added"] = i
+			nested["host"] = "db2"
+			list[0] = "z"
+		}
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := ri.Flush(ctx)
+	close(stop)
+	wg.Wait()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := <-received
+	if report.Extra["count"] != 0.0 || report.Extra["added"] != nil {
+		t.Errorf("Extra = %v, want the values at the time of Add", report.Extra)
+	}
+	if host := report.Extra["nested"].(map[string]interface{})["host"]; host != "db1" {
+		t.Errorf("nested host = %v, want db1", host)
+	}
+	if first := report.Extra["list"].([]interface{})[0]; first != "a" {
+		t.Errorf("list[0] = %v, want a", first)
+	}
+}
+
+// node is a struct with a cyclic pointer, which json.Marshal rejects.
+type node struct {
+	Name string
+	Next *node
+}
+
+func TestSanitizeExtra(t *testing.T) {
+	cyclic := map[string]interface{}{"name": "loop"}
+	cyclic["self"] = cyclic
+	list := []interface{}{"first", nil}
+	list[1] = list
+	deep := map[string]interface{}{}
+	for i, m := 0, deep; i < maxCopyDepth+5; i++ {
+		next := map[string]interface{}{}
+		m["level"] = next
+		m = next
+	}
+	ring := &node{Name: "ring"}
+	ring.Next = ring
+
+	ri := NewReportIssues("test", WithFolder(t.TempDir()))
+	report, ok := ri.AddGet("unmarshallable extra", map[string]interface{}{
+		"chan":   make(chan int),
+		"func":   func() {},
+		"nan":    math.NaN(),
+		"inf":    math.Inf(1),
+		"cyclic": cyclic,
+		"list":   list,
+		"deep":   deep,
+		"ring":   ring,
+		"fine":   "kept",
+	}, LevelError, nil)
+	if !ok || report == nil {
+		t.Fatal("the report was lost")
+	}
+	if _, err := json.Marshal(report); err != nil {
+		t.Fatalf("marshalling the sanitized report: %v", err)
+	}
+
+	sanitized := strings.Split(report.Meta[MetaExtraSanitized], ",")
+	deepPath := "deep" + strings.Repeat(".level", maxCopyDepth)
+	for _, path := range []string{"chan", "func", "nan", "inf", "cyclic.self", "list[1]", "ring", deepPath} {
+		if !slices.Contains(sanitized, path) {
+			t.Errorf("Meta[%q] = %q, lacks %s", MetaExtraSanitized, report.Meta[MetaExtraSanitized], path)
+		}
+	}
+	if report.Extra["fine"] != "kept" || report.Extra["nan"] != "NaN" || report.Extra["inf"] != "+Inf" {
+		t.Errorf("Extra = %v", report.Extra)
+	}
+	if self := report.Extra["cyclic"].(map[string]interface{})["self"]; self != "[cycle]" {
+		t.Errorf("cyclic.self = %v, want [cycle]", self)
+	}
+
+	files, err := os.ReadDir(ri.Options.Folder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("got %d report files, want 1", len(files))
+	}
+}
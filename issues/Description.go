@@ -0,0 +1,44 @@
+package issues
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ExtraFullDescription is the Extra key holding the original description
+// of a report whose description was cut to Options.MaxDescriptionLength.
+const ExtraFullDescription = "full_description"
+
+// normalizeDescription trims desc, collapses its runs of whitespace,
+// newlines included, into single spaces, strips its non-printable
+// characters and cuts it to Options.MaxDescriptionLength characters. It
+// reports whether desc was cut.
+func (ri *ReportIssues) normalizeDescription(desc string) (string, bool) {
+	desc = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsSpace(r):
+			return ' '
+		case !unicode.IsPrint(r):
+			return -1
+		}
+		return r
+	}, desc)
+	desc = strings.Join(strings.Fields(desc), " ")
+
+	limit := ri.Options.MaxDescriptionLength
+	if limit <= 0 || utf8.RuneCountInString(desc) <= limit {
+		return desc, false
+	}
+	runes := []rune(desc)
+	return strings.TrimRight(string(runes[:max(limit-1, 0)]), " ") + "…", true
+}
+
+// keepFullDescription stores the original description of r, which was cut,
+// in its Extra. It is called once r.Extra is the report's own copy.
+func keepFullDescription(r *Report, original string) {
+	if r.Extra == nil {
+		r.Extra = make(map[string]interface{}, 1)
+	}
+	r.Extra[ExtraFullDescription] = original
+}
@@ -0,0 +1,48 @@
+package issues
+
+import (
+	"os"
+	"strconv"
+)
+
+// DisabledEnv is the environment variable which, set to a true value such
+// as 1 when NewReportIssues is called, makes the ReportIssues a no-op, see
+// Disable.
+const DisabledEnv = "COADMIN_DISABLED"
+
+// disabledByEnv reports whether DisabledEnv is set to a true value.
+func disabledByEnv() bool {
+	disabled, _ := strconv.ParseBool(os.Getenv(DisabledEnv))
+	return disabled
+}
+
+// Disable turns ri into a no-op: the Add family and AddReport succeed
+// without generating anything, so no file is written and no request is
+// sent. Reports already buffered are still delivered by the live worker.
+func (ri *ReportIssues) Disable() {
+	ri.disabled.Store(true)
+	ri.LogDebug("Reporting disabled")
+}
+
+// Enable resumes reporting after Disable or DisabledEnv, starting the live
+// worker if it was never started.
+func (ri *ReportIssues) Enable() {
+	ri.disabled.Store(false)
+	if ri.Options.Live {
+		ri.startWorker()
+	}
+	ri.LogDebug("Reporting enabled")
+}
+
+// IsDisabled reports whether ri is disabled, see Disable.
+func (ri *ReportIssues) IsDisabled() bool {
+	return ri.disabled.Load()
+}
+
+// startWorker starts the live worker once. Shutdown prevents it from
+// starting afterwards.
+func (ri *ReportIssues) startWorker() {
+	ri.workerOnce.Do(func() {
+		go ri.liveWorker()
+	})
+}
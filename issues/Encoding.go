@@ -0,0 +1,125 @@
+package issues
+
+import (
+	"encoding/json"
+	"fmt"
+
+	issuesproto "github.com/7c/coadmin-golib/issues/proto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Encodings of the live submissions, see Options.Encoding.
+const (
+	EncodingJSON     = "json"
+	EncodingProtobuf = "protobuf"
+)
+
+// contentType returns the Content-Type of the live submissions.
+func (ri *ReportIssues) contentType() string {
+	if ri.Options.Encoding == EncodingProtobuf {
+		return "application/x-protobuf"
+	}
+	return "application/json"
+}
+
+// marshalSubmission encodes a ReportSubmission or BulkReportSubmission with
+// Options.Encoding.
+func (ri *ReportIssues) marshalSubmission(submission interface{}) ([]byte, error) {
+	if ri.Options.Encoding != EncodingProtobuf {
+		return json.Marshal(submission)
+	}
+	var m proto.Message
+	switch s := submission.(type) {
+	case ReportSubmission:
+		issue, err := ReportToProto(s.Issue)
+		if err != nil {
+			return nil, err
+		}
+		m = &issuesproto.ReportSubmission{Issue: issue}
+	case BulkReportSubmission:
+		bulk := &issuesproto.BulkReportSubmission{Issues: make([]*issuesproto.Report, 0, len(s.Issues))}
+		for _, report := range s.Issues {
+			issue, err := ReportToProto(report)
+			if err != nil {
+				return nil, err
+			}
+			bulk.Issues = append(bulk.Issues, issue)
+		}
+		m = bulk
+	default:
+		return nil, fmt.Errorf("unsupported submission %T", submission)
+	}
+	return proto.Marshal(m)
+}
+
+// ReportToProto converts r to its protobuf form. Extra and Options go
+// through their JSON encoding, it fails when they cannot be marshalled.
+func ReportToProto(r Report) (*issuesproto.Report, error) {
+	extra, err := toStruct(r.Extra)
+	if err != nil {
+		return nil, fmt.Errorf("converting extra of report %d: %w", r.IssueID, err)
+	}
+	options, err := toStruct(r.Options)
+	if err != nil {
+		return nil, fmt.Errorf("converting options of report %d: %w", r.IssueID, err)
+	}
+	return &issuesproto.Report{
+		V:                 int32(r.Version),
+		IssueId:           r.IssueID,
+		IssueId64:         r.IssueID64,
+		Meta:              r.Meta,
+		Options:           options,
+		Caller:            r.Caller,
+		StackTrace:        r.StackTrace,
+		App:               r.App,
+		Extra:             extra,
+		Description:       r.Description,
+		Level:             r.Level,
+		Libversion:        r.LibVersion,
+		T:                 r.T,
+		SuppressedCount:   int64(r.SuppressedCount),
+		FirstSuppressedAt: r.FirstSuppressedAt,
+		Tags:              r.Tags,
+	}, nil
+}
+
+// ProtoToReport converts p back to a Report. As with JSON, the numbers of
+// Extra and Options come back as float64.
+func ProtoToReport(p *issuesproto.Report) Report {
+	return Report{
+		Version:           int(p.GetV()),
+		IssueID:           p.GetIssueId(),
+		IssueID64:         p.GetIssueId64(),
+		Meta:              p.GetMeta(),
+		Options:           p.GetOptions().AsMap(),
+		Caller:            p.GetCaller(),
+		StackTrace:        p.GetStackTrace(),
+		App:               p.GetApp(),
+		Extra:             p.GetExtra().AsMap(),
+		Description:       p.GetDescription(),
+		Level:             p.GetLevel(),
+		LibVersion:        p.GetLibversion(),
+		T:                 p.GetT(),
+		SuppressedCount:   int(p.GetSuppressedCount()),
+		FirstSuppressedAt: p.GetFirstSuppressedAt(),
+		Tags:              p.GetTags(),
+	}
+}
+
+// toStruct converts m to a structpb.Struct through its JSON encoding, which
+// supports the value types structpb.NewStruct does not, e.g. []string.
+func toStruct(m map[string]interface{}) (*structpb.Struct, error) {
+	if m == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	s := &structpb.Struct{}
+	if err := s.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,97 @@
+package issues
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// EncryptedExt is appended to the name of the report files encrypted with
+// Options.EncryptionKey.
+const EncryptedExt = ".enc"
+
+// ErrInvalidEncryptionKey is returned when an encryption key is not 32 bytes
+// long, as required by AES-256.
+var ErrInvalidEncryptionKey = errors.New("encryption key must be 32 bytes")
+
+// ErrEncryptedReport is returned by ParseReportFile for an encrypted report
+// file when no key is given.
+var ErrEncryptedReport = errors.New("report file is encrypted")
+
+// newGCM returns the AES-256-GCM cipher for key.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, ErrInvalidEncryptionKey
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encrypt seals data with AES-256-GCM, the random nonce being prepended to
+// the ciphertext.
+func encrypt(data, key []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, data, nil), nil
+}
+
+// decrypt opens data sealed by encrypt.
+func decrypt(data, key []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+// DecryptReportFile reads a report file encrypted with Options.EncryptionKey.
+func DecryptReportFile(path string, key []byte) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := decrypt(data, key)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting %s: %w", path, err)
+	}
+	var report Report
+	if err := json.Unmarshal(plain, &report); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &report, nil
+}
+
+// ParseReportFile reads a report file written in file mode. Files ending
+// with EncryptedExt are decrypted with key, ErrEncryptedReport is returned
+// when key is nil.
+func ParseReportFile(path string, key []byte) (*Report, error) {
+	if strings.HasSuffix(path, EncryptedExt) {
+		if key == nil {
+			return nil, fmt.Errorf("%w: %s", ErrEncryptedReport, path)
+		}
+		return DecryptReportFile(path, key)
+	}
+	report, err := ReadReportFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
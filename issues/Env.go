@@ -0,0 +1,91 @@
+package issues
+
+import (
+	"os"
+	"regexp"
+	"strings"
+)
+
+// EnvPrefix prefixes the names of the environment variables captured by
+// Options.CaptureEnvVars and Options.CaptureAllEnv.
+const EnvPrefix = "env_"
+
+// compileEnvRedactPatterns compiles Options.EnvRedactPatterns. An invalid
+// pattern is logged and redacts every variable, rather than leaking them.
+func (ri *ReportIssues) compileEnvRedactPatterns() {
+	for _, pattern := range ri.Options.EnvRedactPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			ri.LogDebug("Invalid EnvRedactPatterns entry %q, redacting all variables: %v", pattern, err)
+			re = regexp.MustCompile("")
+		}
+		ri.envRedact = append(ri.envRedact, re)
+	}
+}
+
+// captureEnv returns the environment variables to attach to a report, keyed
+// with the EnvPrefix, nil when none is captured. Unset variables are left
+// out. The values of the variables matching EnvRedactPatterns or RedactKeys
+// are replaced with Redacted.
+func (ri *ReportIssues) captureEnv() map[string]string {
+	if !ri.Options.CaptureAllEnv && len(ri.Options.CaptureEnvVars) == 0 {
+		return nil
+	}
+	env := make(map[string]string)
+	if ri.Options.CaptureAllEnv {
+		for _, entry := range os.Environ() {
+			if name, value, ok := strings.Cut(entry, "="); ok && name != "" {
+				env[name] = value
+			}
+		}
+	} else {
+		for _, name := range ri.Options.CaptureEnvVars {
+			if value, ok := os.LookupEnv(name); ok {
+				env[name] = value
+			}
+		}
+	}
+	captured := make(map[string]string, len(env))
+	for name, value := range env {
+		if ri.redactEnv(name) {
+			value = Redacted
+		}
+		captured[EnvPrefix+name] = value
+	}
+	return captured
+}
+
+// redactEnv reports whether the value of the environment variable name must
+// be redacted.
+func (ri *ReportIssues) redactEnv(name string) bool {
+	for _, re := range ri.envRedact {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return ri.redactKey(name)
+}
+
+// addEnv attaches the captured environment variables to the Meta of the
+// report, or to its Extra with Options.EnvInExtra.
+func (ri *ReportIssues) addEnv(r *Report) {
+	env := ri.captureEnv()
+	if len(env) == 0 {
+		return
+	}
+	if !ri.Options.EnvInExtra {
+		if r.Meta == nil {
+			r.Meta = make(map[string]string, len(env))
+		}
+		for key, value := range env {
+			r.Meta[key] = value
+		}
+		return
+	}
+	if r.Extra == nil {
+		r.Extra = make(map[string]interface{}, len(env))
+	}
+	for key, value := range env {
+		r.Extra[key] = value
+	}
+}
@@ -0,0 +1,24 @@
+package issues
+
+import (
+	"os"
+	"strings"
+)
+
+// EnvironmentEnv is the environment variable read by NewReportIssues when
+// Options.Environment is empty, e.g. COADMIN_ENV=staging.
+const EnvironmentEnv = "COADMIN_ENV"
+
+// MetaEnvironment is the meta key holding Options.Environment.
+const MetaEnvironment = "env"
+
+// resolveEnvironment falls back to EnvironmentEnv when Options.Environment
+// is empty, and records the environment in Meta.
+func (ri *ReportIssues) resolveEnvironment() {
+	if ri.Options.Environment == "" {
+		ri.Options.Environment = strings.TrimSpace(os.Getenv(EnvironmentEnv))
+	}
+	if ri.Options.Environment != "" {
+		ri.Meta[MetaEnvironment] = ri.Options.Environment
+	}
+}
@@ -0,0 +1,61 @@
+package issues
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ReportExt ends the name of every report file, so ListReports,
+// LoadReports and ReplayFromFiles find them.
+const ReportExt = ".coadmin_issue"
+
+// defaultFilenameTemplate names report files after their IssueID, so a new
+// occurrence of an issue replaces the previous file.
+const defaultFilenameTemplate = "{id}" + ReportExt
+
+// ErrInvalidFilenameTemplate is returned when Options.FilenameTemplate uses
+// an unknown placeholder, is not a plain file name or lacks ReportExt.
+var ErrInvalidFilenameTemplate = errors.New("invalid filename template")
+
+// filenamePlaceholder matches the placeholders of a filename template.
+var filenamePlaceholder = regexp.MustCompile(`\{[^{}]*\}`)
+
+// ValidateFilenameTemplate checks that tmpl only uses the {id}, {level},
+// {app} and {timestamp} placeholders, names a file, not a path, and ends
+// with ReportExt. An empty template stands for the default one.
+func ValidateFilenameTemplate(tmpl string) error {
+	if tmpl == "" {
+		return nil
+	}
+	if strings.ContainsAny(tmpl, `/\`) {
+		return fmt.Errorf("%w %q: must not contain a path separator", ErrInvalidFilenameTemplate, tmpl)
+	}
+	if !strings.HasSuffix(tmpl, ReportExt) {
+		return fmt.Errorf("%w %q: must end with %s", ErrInvalidFilenameTemplate, tmpl, ReportExt)
+	}
+	for _, placeholder := range filenamePlaceholder.FindAllString(tmpl, -1) {
+		switch placeholder {
+		case "{id}", "{level}", "{app}", "{timestamp}":
+		default:
+			return fmt.Errorf("%w %q: unknown placeholder %s", ErrInvalidFilenameTemplate, tmpl, placeholder)
+		}
+	}
+	return nil
+}
+
+// reportFilename returns the name of the file the report is written to.
+func (ri *ReportIssues) reportFilename(report *Report) string {
+	tmpl := ri.Options.FilenameTemplate
+	if tmpl == "" {
+		tmpl = defaultFilenameTemplate
+	}
+	return strings.NewReplacer(
+		"{id}", strconv.FormatUint(report.id(), 10),
+		"{level}", report.Level,
+		"{app}", strings.NewReplacer("/", "_", `\`, "_").Replace(report.App),
+		"{timestamp}", strconv.FormatInt(report.T, 10),
+	).Replace(tmpl)
+}
@@ -0,0 +1,106 @@
+package issues
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"hash/fnv"
+	"slices"
+	"strings"
+)
+
+// FNV32Hasher is an IDHasher based on 32-bit FNV-1a.
+var FNV32Hasher = func(input string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(input))
+	return h.Sum32()
+}
+
+// SHA256Hasher is an IDHasher using the first 32 bits of a SHA-256 digest.
+var SHA256Hasher = func(input string) uint32 {
+	sum := sha256.Sum256([]byte(input))
+	return binary.BigEndian.Uint32(sum[:4])
+}
+
+// Values of Options.HashAlgorithm.
+const (
+	// HashCRC32 is the 32-bit CRC-32 (IEEE) of the issue, the default.
+	HashCRC32 = "crc32"
+	// HashFNV64 is the 64-bit FNV-1a of the issue, sent in IssueID64.
+	HashFNV64 = "fnv64"
+)
+
+// issueHash computes the hash identifying an issue, used both as IssueID and
+// to throttle duplicate issues. It is 64 bits wide with a 64-bit
+// HashAlgorithm, and fits in 32 bits otherwise. Options.Environment is part
+// of the hash when set.
+func (ri *ReportIssues) issueHash(app, level, issue string) uint64 {
+	hashInput := strings.ToLower(fmt.Sprintf("%s_issue_%s_%s", app, level, issue))
+	if env := ri.Options.Environment; env != "" {
+		hashInput = strings.ToLower(env) + "_" + hashInput
+	}
+	if ri.Options.IDHasher != nil {
+		return uint64(ri.Options.IDHasher(hashInput))
+	}
+	if ri.hash64() {
+		h := fnv.New64a()
+		h.Write([]byte(hashInput))
+		return h.Sum64()
+	}
+	return uint64(crc32.ChecksumIEEE([]byte(hashInput)))
+}
+
+// hash64 reports whether issues are identified by a 64-bit hash.
+func (ri *ReportIssues) hash64() bool {
+	return ri.Options.IDHasher == nil && ri.Options.HashAlgorithm == HashFNV64
+}
+
+// setIssueID sets the IssueID of the report from hash, and IssueID64 when
+// the hash is 64 bits wide.
+func (ri *ReportIssues) setIssueID(r *Report, hash uint64) {
+	r.IssueID = uint32(hash)
+	if ri.hash64() {
+		r.IssueID64 = hash
+	}
+}
+
+// groupHash returns the throttle hash of an issue of ri.AppName, grouped by
+// groupKey: the normalized description, or the fingerprint or format that
+// replaces it.
+func (ri *ReportIssues) groupHash(groupKey, level string, extra map[string]interface{}) uint64 {
+	return ri.issueHash(ri.AppName, level, ri.withHashExtra(groupKey, extra))
+}
+
+// withHashExtra appends the values of Options.HashExtraKeys found in extra to
+// groupKey, sorted by key so the configured order does not matter. Missing
+// keys contribute an empty value, non-string values their JSON encoding.
+func (ri *ReportIssues) withHashExtra(groupKey string, extra map[string]interface{}) string {
+	if len(ri.Options.HashExtraKeys) == 0 {
+		return groupKey
+	}
+	keys := slices.Clone(ri.Options.HashExtraKeys)
+	slices.Sort(keys)
+	var b strings.Builder
+	b.WriteString(groupKey)
+	for _, key := range slices.Compact(keys) {
+		fmt.Fprintf(&b, "\x00%s=%s", key, hashExtraValue(extra[key]))
+	}
+	return b.String()
+}
+
+// hashExtraValue renders an Extra value deterministically for hashing.
+func hashExtraValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
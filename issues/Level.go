@@ -0,0 +1,84 @@
+package issues
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Level is the severity of a reported issue.
+type Level string
+
+// Supported levels, from the most to the least severe.
+const (
+	LevelFatal   Level = "fatal"
+	LevelError   Level = "error"
+	LevelWarning Level = "warning"
+	LevelInfo    Level = "info"
+	LevelDebug   Level = "debug"
+)
+
+// Levels lists every supported level, from the most to the least severe.
+var Levels = []Level{LevelFatal, LevelError, LevelWarning, LevelInfo, LevelDebug}
+
+// ErrInvalidLevel is returned when an issue is added with an unknown level.
+var ErrInvalidLevel = errors.New("invalid level")
+
+// IsValid reports whether l is one of the supported levels.
+func (l Level) IsValid() bool {
+	for _, level := range Levels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
+
+// severity ranks the level, higher is more severe. Unknown levels rank -1.
+func (l Level) severity() int {
+	for i, level := range Levels {
+		if l == level {
+			return len(Levels) - i
+		}
+	}
+	return -1
+}
+
+// SetMinLevel changes Options.MinLevel at runtime, empty reports every level.
+func (ri *ReportIssues) SetMinLevel(level Level) {
+	ri.Mutex.Lock()
+	defer ri.Mutex.Unlock()
+	ri.Options.MinLevel = level
+}
+
+// belowMinLevel reports whether a normalized level is filtered by MinLevel.
+func (ri *ReportIssues) belowMinLevel(level string) bool {
+	ri.Mutex.Lock()
+	minLevel := ri.Options.MinLevel
+	ri.Mutex.Unlock()
+	return minLevel != "" && Level(level).severity() < minLevel.severity()
+}
+
+// ParseLevel returns the level named s, case-insensitively and ignoring
+// surrounding spaces, or an error wrapping ErrInvalidLevel.
+func ParseLevel(s string) (Level, error) {
+	level := Level(strings.ToLower(strings.TrimSpace(s)))
+	if !level.IsValid() {
+		return "", fmt.Errorf("%w %q", ErrInvalidLevel, s)
+	}
+	return level, nil
+}
+
+// normalizeLevel parses the level, see ParseLevel. Unknown levels are
+// rejected, or replaced by "error" when Options.FallbackToErrorLevel is set.
+func (ri *ReportIssues) normalizeLevel(level Level) (string, error) {
+	parsed, err := ParseLevel(string(level))
+	if err == nil {
+		return string(parsed), nil
+	}
+	if ri.Options.FallbackToErrorLevel {
+		ri.LogDebug("Unknown level %q, falling back to %q", level, LevelError)
+		return string(LevelError), nil
+	}
+	return "", err
+}
@@ -0,0 +1,540 @@
+package issues
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// retryEntry is a report waiting to be resent after failed submissions.
+type retryEntry struct {
+	report   Report
+	attempts int       // failed attempts so far
+	next     time.Time // when the report may be sent again
+}
+
+// liveWorker sends buffered reports to the server, one batch per second,
+// until the ReportIssues is shut down. Reports that fail are retried with
+// exponential backoff, and nothing is sent while the circuit is open or for
+// the Retry-After delay of a 429 or 503 response. Requests are bound to
+// ri.ctx, so Shutdown aborts a send in progress instead of waiting for
+// RequestTimeout.
+func (ri *ReportIssues) liveWorker() {
+	defer close(ri.workerDone)
+	ri.LogDebug("Starting live worker")
+	for {
+		if ri.ctx.Err() != nil {
+			// Shut down while a retry was due, leave the rest to Shutdown.
+			ri.LogDebug("Live worker stopped")
+			return
+		}
+		paused := ri.pausedFor(ri.now())
+		if paused == 0 && ri.circuitAllow(ri.now()) {
+			ri.sendPending()
+		}
+		if ri.pendingLen() == 0 {
+			ri.notifyFlushed()
+		}
+		wait := ri.nextWake(ri.now())
+		if paused = ri.pausedFor(ri.now()); paused > 0 {
+			// Honor the Retry-After of the server.
+			wait = paused
+		} else if ri.flushRequested() && ri.BufferLen() > 0 && ri.GetCircuitState() != CircuitOpen {
+			// Someone is waiting in Flush, keep sending.
+			wait = 0
+		}
+		ri.LogDebug("Sleeping for %s, buffer size: %d", wait, ri.BufferLen())
+		select {
+		case <-ri.done:
+			ri.LogDebug("Live worker stopped")
+			return
+		case <-ri.wake:
+		case <-time.After(wait):
+		}
+	}
+}
+
+// sendPending submits the retries due and a batch from the buffer, unless
+// the retries opened the circuit.
+func (ri *ReportIssues) sendPending() {
+	for _, entry := range ri.popDueRetries(ri.now()) {
+		ri.logIssue("retry", entry.report.id(), entry.report.Level, "Retrying IssueID %d, attempt %d", entry.report.IssueID, entry.attempts+1)
+		if _, err := ri.submit(ri.ctx, []Report{entry.report}); err != nil {
+			ri.logIssue("failed", entry.report.id(), entry.report.Level, "Sending IssueID %d failed: %v", entry.report.IssueID, err)
+			ri.scheduleRetry(entry.report, entry.attempts+1, err)
+		}
+		ri.inflight.Add(-1)
+	}
+	if ri.GetCircuitState() == CircuitOpen {
+		return
+	}
+	if batch := ri.popBuffer(); len(batch) > 0 {
+		ri.LogDebug("Processing %d reports from buffer", len(batch))
+		if sent, err := ri.submit(ri.ctx, batch); err != nil {
+			ri.LogDebug("Sending %d reports failed: %v", len(batch)-sent, err)
+			for _, report := range batch[sent:] {
+				ri.scheduleRetry(report, 1, err)
+			}
+		}
+		ri.inflight.Add(-int32(len(batch)))
+	}
+}
+
+// Flush wakes the live worker and blocks until every buffered report, pending
+// retries included, has been handled or ctx is done. It returns ErrClosed when
+// the worker was shut down before the buffer was empty.
+func (ri *ReportIssues) Flush(ctx context.Context) error {
+	if !ri.Options.Live || ri.pendingLen() == 0 {
+		return nil
+	}
+	flushed := make(chan struct{})
+	ri.Mutex.Lock()
+	ri.flushWaiters = append(ri.flushWaiters, flushed)
+	ri.Mutex.Unlock()
+	select {
+	case ri.wake <- struct{}{}:
+	default:
+	}
+
+	select {
+	case <-flushed:
+		return nil
+	case <-ri.workerDone:
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// FlushBuffer sends every buffered report from the calling goroutine, in
+// batches of BatchSize, until the buffer is empty or ctx is done. Unlike
+// Flush it does not depend on the live worker, which makes it suitable for
+// a SIGTERM handler. Reports that fail are queued for retry and all errors
+// are returned joined together.
+func (ri *ReportIssues) FlushBuffer(ctx context.Context) error {
+	var errs []error
+	for {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		batch := ri.popBuffer()
+		if len(batch) == 0 {
+			break
+		}
+		if sent, err := ri.submit(ctx, batch); err != nil {
+			errs = append(errs, err)
+			for _, report := range batch[sent:] {
+				ri.scheduleRetry(report, 1, err)
+			}
+		}
+		ri.inflight.Add(-int32(len(batch)))
+	}
+	return errors.Join(errs...)
+}
+
+// flushRequested reports whether a Flush call is waiting.
+func (ri *ReportIssues) flushRequested() bool {
+	ri.Mutex.Lock()
+	defer ri.Mutex.Unlock()
+	return len(ri.flushWaiters) > 0
+}
+
+// notifyFlushed releases the Flush calls waiting for an empty buffer.
+func (ri *ReportIssues) notifyFlushed() {
+	ri.Mutex.Lock()
+	defer ri.Mutex.Unlock()
+	for _, flushed := range ri.flushWaiters {
+		close(flushed)
+	}
+	ri.flushWaiters = nil
+}
+
+// scheduleRetry queues a report that failed attempts times for another try.
+// Once MaxRetries is exhausted the report is written to the folder when
+// FallbackToFile is set, or dropped.
+func (ri *ReportIssues) scheduleRetry(report Report, attempts int, err error) {
+	if ri.ctx.Err() != nil {
+		// Aborted by Shutdown, which sends it again; this attempt does not count.
+		ri.Mutex.Lock()
+		ri.retries = append(ri.retries, retryEntry{report: report, attempts: attempts - 1, next: ri.now()})
+		ri.Mutex.Unlock()
+		return
+	}
+	if !ri.retryable(err) {
+		ri.logIssue("dropped", report.id(), report.Level, "Dropping IssueID %d rejected by the server: %v", report.IssueID, err)
+		ri.dropReport(report, err)
+		return
+	}
+	if attempts > ri.Options.MaxRetries {
+		if ri.Options.FallbackToFile {
+			fileErr := ri.writeFile(&report)
+			if fileErr == nil {
+				ri.logIssue("written", report.id(), report.Level, "IssueID %d written to file after %d failed attempts", report.IssueID, attempts)
+				return
+			}
+			err = errors.Join(err, fileErr)
+		}
+		ri.logIssue("dropped", report.id(), report.Level, "Dropping IssueID %d after %d failed attempts", report.IssueID, attempts)
+		ri.dropReport(report, err)
+		return
+	}
+	delay := ri.Options.RetryBaseInterval << (attempts - 1)
+	ri.Mutex.Lock()
+	ri.retries = append(ri.retries, retryEntry{report: report, attempts: attempts, next: ri.now().Add(delay)})
+	ri.Mutex.Unlock()
+	ri.logIssue("retry_scheduled", report.id(), report.Level, "IssueID %d will be retried in %s", report.IssueID, delay)
+}
+
+// dropReport counts a report given up on and passes it to Options.OnDropped.
+func (ri *ReportIssues) dropReport(report Report, err error) {
+	ri.stats.dropped.Add(1)
+	if ri.Options.OnDropped != nil {
+		ri.Options.OnDropped(report, err)
+	}
+}
+
+// popDueRetries removes and returns the retry entries due at now. The caller
+// must call ri.inflight.Add(-1) once done with each of them.
+func (ri *ReportIssues) popDueRetries(now time.Time) []retryEntry {
+	ri.Mutex.Lock()
+	defer ri.Mutex.Unlock()
+	var due []retryEntry
+	pending := ri.retries[:0]
+	for _, entry := range ri.retries {
+		if now.Before(entry.next) {
+			pending = append(pending, entry)
+		} else {
+			due = append(due, entry)
+		}
+	}
+	ri.retries = pending
+	// Counted before the lock is released, so pendingLen never misses them.
+	ri.inflight.Add(int32(len(due)))
+	return due
+}
+
+// nextWake returns how long the worker sleeps: one second, or less when a
+// retry becomes due earlier.
+func (ri *ReportIssues) nextWake(now time.Time) time.Duration {
+	wait := 1 * time.Second
+	ri.Mutex.Lock()
+	defer ri.Mutex.Unlock()
+	for _, entry := range ri.retries {
+		if d := entry.next.Sub(now); d < wait {
+			wait = max(d, 0)
+		}
+	}
+	return wait
+}
+
+// batchSize returns the number of reports sent per request.
+func (ri *ReportIssues) batchSize() int {
+	if ri.Options.BatchSize < 1 {
+		return 1
+	}
+	return ri.Options.BatchSize
+}
+
+// popBuffer receives up to batchSize of the oldest buffered reports without
+// blocking. The caller must call ri.inflight.Add(-len(batch)) once done.
+func (ri *ReportIssues) popBuffer() []Report {
+	var batch []Report
+	for len(batch) < ri.batchSize() {
+		select {
+		case report := <-ri.buffer:
+			ri.inflight.Add(1)
+			batch = append(batch, report)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+// pendingLen returns the number of reports not yet submitted, including the
+// ones being sent and the ones waiting for a retry.
+func (ri *ReportIssues) pendingLen() int {
+	ri.Mutex.Lock()
+	retries := len(ri.retries)
+	ri.Mutex.Unlock()
+	return len(ri.buffer) + int(ri.inflight.Load()) + retries
+}
+
+// SubmitReport sends a report to the server right away, the way the live
+// worker does, bypassing throttling and the buffer. It is meant for reports
+// generated elsewhere, e.g. read back with ReadReportFile.
+func (ri *ReportIssues) SubmitReport(ctx context.Context, report Report) error {
+	_, err := ri.submit(ctx, []Report{report})
+	return err
+}
+
+// submit delivers a batch of reports through the backend, see deliver. With
+// the HTTP backend and a BatchSize above one the batch is sent as a
+// BulkReportSubmission, to BulkServer when set. It returns the number of
+// leading reports of batch delivered, all of them when the error is nil.
+func (ri *ReportIssues) submit(ctx context.Context, batch []Report) (int, error) {
+	if len(batch) > 1 {
+		ri.LogDebug("Sending %d reports", len(batch))
+	} else {
+		ri.logIssue("sending", batch[0].id(), batch[0].Level, "Sending IssueID %d", batch[0].IssueID)
+	}
+	sent, status, err := ri.deliver(ctx, batch)
+	if delay := ri.retryAfter(err); delay > 0 {
+		ri.pauseSending(delay)
+	}
+	ri.circuitResult(err)
+	ri.notifyDelivery(batch[:sent], status, nil)
+	ri.stats.submitted.Add(int64(sent))
+	ri.removeReplayed(batch[:sent])
+	if err != nil {
+		ri.notifyDelivery(batch[sent:], status, err)
+		ri.stats.failed.Add(int64(len(batch) - sent))
+		return sent, err
+	}
+	return sent, nil
+}
+
+// notifyDelivery calls OnSubmitted or OnFailed with a copy of every report
+// of batch, after a delivery attempt.
+func (ri *ReportIssues) notifyDelivery(batch []Report, status int, err error) {
+	if ri.Options.OnSubmitted == nil && ri.Options.OnFailed == nil {
+		return
+	}
+	for _, report := range batch {
+		report := report.clone()
+		if err != nil {
+			if ri.Options.OnFailed != nil {
+				ri.callback("OnFailed", func() { ri.Options.OnFailed(report, err) })
+			}
+		} else if ri.Options.OnSubmitted != nil {
+			ri.callback("OnSubmitted", func() { ri.Options.OnSubmitted(report, status) })
+		}
+	}
+}
+
+// callback calls fn, recovering and logging its panics so user callbacks
+// cannot kill the live worker.
+func (ri *ReportIssues) callback(name string, fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			ri.LogDebug("%s panicked: %v", name, r)
+		}
+	}()
+	fn()
+}
+
+// post sends the body, encoded with Options.Encoding, to server, signed when
+// HMACSecret is set and gzipped when CompressRequests is set and it exceeds
+// CompressMinBytes. A 4xx or 5xx response is a StatusError. It returns the
+// status code of the response, zero when none was received.
+func (ri *ReportIssues) post(ctx context.Context, server string, body []byte) (int, error) {
+	req := ri.restyClient.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", ri.contentType())
+	if ri.Options.APIKey != "" {
+		req.SetHeader(ri.authHeader())
+	}
+	if ri.Options.HMACSecret != nil {
+		req.SetHeader(SignatureHeader, Sign(body, ri.Options.HMACSecret))
+	}
+	if ri.Options.CompressRequests && len(body) > ri.Options.CompressMinBytes {
+		compressed, err := gzipBody(body)
+		if err != nil {
+			return 0, fmt.Errorf("compressing request: %w", err)
+		}
+		req.SetHeader("Content-Encoding", "gzip")
+		body = compressed
+	}
+	req.SetBody(body)
+	resp, err := req.Post(server)
+	if err != nil {
+		return 0, err
+	}
+	ri.LogDebug("HTTP request sent, response status: %s", resp.Status())
+	if resp.StatusCode() >= 400 {
+		statusErr := &StatusError{StatusCode: resp.StatusCode(), Status: resp.Status()}
+		statusErr.RetryAfter, _ = parseRetryAfter(resp.Header().Get("Retry-After"), ri.now())
+		return resp.StatusCode(), statusErr
+	}
+	return resp.StatusCode(), nil
+}
+
+// StatusError is returned when the server answers a submission with a 4xx or
+// 5xx status code.
+type StatusError struct {
+	StatusCode int
+	Status     string
+	// RetryAfter is the delay of the Retry-After header, zero without one.
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return "server responded with " + e.Status
+}
+
+// DefaultRetryableStatusCodes is the default value of
+// Options.RetryableStatusCodes, also used when it is nil.
+var DefaultRetryableStatusCodes = []int{408, 429, 500, 502, 503, 504}
+
+// retryable reports whether a submission failing with err may be retried:
+// any error but a StatusError whose code is not in RetryableStatusCodes.
+func (ri *ReportIssues) retryable(err error) bool {
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		return true
+	}
+	codes := ri.Options.RetryableStatusCodes
+	if codes == nil {
+		codes = DefaultRetryableStatusCodes
+	}
+	return slices.Contains(codes, statusErr.StatusCode)
+}
+
+// authHeader returns the name and value of the authentication header. A key
+// sent in the Authorization header without a scheme is sent as a Bearer token.
+func (ri *ReportIssues) authHeader() (string, string) {
+	header := ri.Options.AuthHeader
+	if header == "" {
+		header = "Authorization"
+	}
+	value := ri.Options.APIKey
+	if strings.EqualFold(header, "Authorization") && !strings.Contains(value, " ") {
+		value = "Bearer " + value
+	}
+	return header, value
+}
+
+// Shutdown stops the live worker, aborting its request in progress, and sends
+// the remaining buffered reports until ctx is done. Reports that could not be
+// submitted are returned, along with ctx.Err() if the deadline cut the drain
+// short. Calling Shutdown again only drains whatever was buffered since.
+func (ri *ReportIssues) Shutdown(ctx context.Context) ([]Report, error) {
+	ri.shutdownOnce.Do(func() {
+		ri.LogDebug("Shutting down")
+		// Mark the worker as done when it was never started.
+		ri.workerOnce.Do(func() { close(ri.workerDone) })
+		close(ri.done)
+		ri.cancel()
+		if ri.Options.ThrottleStateFile != "" {
+			if err := ri.saveThrottleState(); err != nil {
+				ri.LogDebug("Shutdown: %v", err)
+			}
+		}
+	})
+	if ri.Options.Live {
+		select {
+		case <-ri.workerDone:
+		case <-ctx.Done():
+			return ri.takeBuffer(), ctx.Err()
+		}
+	}
+
+	// Pending retries go first, they are older than the buffered reports.
+	var failed []Report
+	queue := ri.takeRetries()
+	for {
+		if err := ctx.Err(); err != nil {
+			return append(append(failed, queue...), ri.takeBuffer()...), err
+		}
+		var batch []Report
+		if len(queue) > 0 {
+			n := min(ri.batchSize(), len(queue))
+			batch, queue = queue[:n], queue[n:]
+		} else if batch = ri.popBuffer(); len(batch) == 0 {
+			break
+		} else {
+			ri.inflight.Add(-int32(len(batch)))
+		}
+		if sent, err := ri.submit(ctx, batch); err != nil {
+			ri.LogDebug("Shutdown: failed to submit %d reports: %v", len(batch)-sent, err)
+			if !ri.retryable(err) {
+				for _, report := range batch[sent:] {
+					ri.dropReport(report, err)
+				}
+				continue
+			}
+			failed = append(failed, batch[sent:]...)
+		}
+	}
+	if len(failed) > 0 {
+		return failed, fmt.Errorf("%d reports could not be submitted", len(failed))
+	}
+	return nil, nil
+}
+
+// Close stops the live worker after draining the buffer, bounded by the ctx
+// deadline. Reports that could not be sent are dropped, or saved to the
+// BufferFile with Options.PersistBuffer. Close is idempotent: only the first
+// call does any work, later calls return nil.
+func (ri *ReportIssues) Close(ctx context.Context) error {
+	var err error
+	ri.closeOnce.Do(func() {
+		var remaining []Report
+		remaining, err = ri.Shutdown(ctx)
+		if len(remaining) == 0 {
+			return
+		}
+		if ri.Options.PersistBuffer {
+			persistErr := ri.persistBuffer(remaining)
+			if persistErr == nil {
+				ri.LogDebug("Close: saved %d unsent reports to %s", len(remaining), ri.bufferFile())
+				return
+			}
+			ri.LogDebug("Close: %v", persistErr)
+		}
+		ri.LogDebug("Close: dropping %d unsent reports", len(remaining))
+	})
+	return err
+}
+
+// takeRetries removes the pending retries and returns their reports.
+func (ri *ReportIssues) takeRetries() []Report {
+	ri.Mutex.Lock()
+	defer ri.Mutex.Unlock()
+	var reports []Report
+	for _, entry := range ri.retries {
+		reports = append(reports, entry.report)
+	}
+	ri.retries = nil
+	return reports
+}
+
+// takeBuffer empties the buffer and the pending retries and returns them.
+func (ri *ReportIssues) takeBuffer() []Report {
+	remaining := ri.takeRetries()
+	for {
+		select {
+		case report := <-ri.buffer:
+			remaining = append(remaining, report)
+		default:
+			return remaining
+		}
+	}
+}
+
+// gzipWriters pools the gzip writers of gzipBody, each holding about 1 MB
+// of compression state.
+var gzipWriters = sync.Pool{
+	New: func() any { return gzip.NewWriter(nil) },
+}
+
+// gzipBody returns body compressed with gzip.
+func gzipBody(body []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzipWriters.Get().(*gzip.Writer)
+	defer gzipWriters.Put(zw)
+	zw.Reset(&buf)
+	if _, err := zw.Write(body); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
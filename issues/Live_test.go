@@ -0,0 +1,475 @@
+package issues
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// recordingLogger is a Logger keeping the messages it receives.
+type recordingLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *recordingLogger) Debugf(format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messages = append(l.messages, fmt.Sprintf(format, args...))
+}
+
+func (l *recordingLogger) contains(s string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, message := range l.messages {
+		if strings.Contains(message, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// newLiveReporter returns a live ReportIssues sending to handler, shut down
+// at the end of the test.
+func newLiveReporter(t *testing.T, handler http.HandlerFunc, options ...func(*Options)) *ReportIssues {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	options = append([]func(*Options){
+		WithLive(true),
+		WithServer(srv.URL),
+		WithFolder(t.TempDir()),
+		WithRetryBaseInterval(10 * time.Millisecond),
+	}, options...)
+	ri := NewReportIssues("test", options...)
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		ri.Shutdown(ctx)
+	})
+	return ri
+}
+
+func TestLiveFailuresGoToLogger(t *testing.T) {
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	logger := &recordingLogger{}
+	ri := newLiveReporter(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}, WithMaxRetries(1), WithDebug(true), WithLogger(logger))
+	ri.Error("failing", nil, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := ri.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	os.Stdout = stdout
+	w.Close()
+	printed, _ := io.ReadAll(r)
+	if len(printed) > 0 {
+		t.Errorf("live worker printed to stdout: %q", printed)
+	}
+	if !logger.contains("failed") {
+		t.Error("the failed send was not logged to the Logger")
+	}
+}
+
+func TestRetryableStatusCodes(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		codes  []int
+		status int
+		want   bool
+	}{
+		{"default 503", DefaultRetryableStatusCodes, 503, true},
+		{"default 429", DefaultRetryableStatusCodes, 429, true},
+		{"default 400", DefaultRetryableStatusCodes, 400, false},
+		{"nil 500", nil, 500, true},
+		{"nil 404", nil, 404, false},
+		{"empty 500", []int{}, 500, false},
+		{"custom 400", []int{400}, 400, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ri := NewReportIssues("test", func(o *Options) { o.RetryableStatusCodes = tc.codes })
+			err := fmt.Errorf("posting: %w", &StatusError{StatusCode: tc.status})
+			if got := ri.retryable(err); got != tc.want {
+				t.Errorf("retryable(%d) = %v, want %v", tc.status, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	const base = 20 * time.Millisecond
+	var (
+		mu       sync.Mutex
+		arrivals []time.Time
+	)
+	dropped := make(chan error, 1)
+	ri := newLiveReporter(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		arrivals = append(arrivals, time.Now())
+		mu.Unlock()
+		w.WriteHeader(http.StatusInternalServerError)
+	}, WithMaxRetries(3), WithRetryBaseInterval(base), WithOnDropped(func(r Report, err error) {
+		dropped <- err
+	}))
+	ri.Add("retried", nil, LevelError, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := ri.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-dropped:
+		var statusErr *StatusError
+		if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusInternalServerError {
+			t.Errorf("OnDropped error = %v, want a 500 StatusError", err)
+		}
+	default:
+		t.Fatal("OnDropped was not called")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(arrivals) != 4 {
+		t.Fatalf("server got %d requests, want 1 plus 3 retries", len(arrivals))
+	}
+	for i := 1; i < len(arrivals); i++ {
+		if gap, want := arrivals[i].Sub(arrivals[i-1]), base<<(i-1); gap < want {
+			t.Errorf("retry %d came after %s, want at least %s", i, gap, want)
+		}
+	}
+}
+
+func TestStatusClasses(t *testing.T) {
+	for _, tt := range []struct {
+		status       int
+		wantRequests int32
+		wantDropped  bool
+	}{
+		{http.StatusOK, 1, false},
+		{http.StatusBadRequest, 1, true},
+		{http.StatusNotFound, 1, true},
+		{http.StatusTooManyRequests, 2, false},
+		{http.StatusInternalServerError, 2, false},
+		{http.StatusServiceUnavailable, 2, false},
+	} {
+		t.Run(strconv.Itoa(tt.status), func(t *testing.T) {
+			var requests atomic.Int32
+			var dropped atomic.Bool
+			ri := newLiveReporter(t, func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) == 1 {
+					w.WriteHeader(tt.status)
+				}
+			}, WithOnDropped(func(Report, error) { dropped.Store(true) }))
+			ri.Add("status class", nil, LevelError, nil)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := ri.Flush(ctx); err != nil {
+				t.Fatal(err)
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("server got %d requests, want %d", got, tt.wantRequests)
+			}
+			if dropped.Load() != tt.wantDropped {
+				t.Errorf("dropped = %v, want %v", dropped.Load(), tt.wantDropped)
+			}
+			wantSubmitted := int64(1)
+			if tt.wantDropped {
+				wantSubmitted = 0
+			}
+			if got := ri.GetStats().TotalSubmitted; got != wantSubmitted {
+				t.Errorf("TotalSubmitted = %d, want %d", got, wantSubmitted)
+			}
+		})
+	}
+}
+
+func TestFallbackToFile(t *testing.T) {
+	var dropped atomic.Bool
+	ri := newLiveReporter(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}, WithMaxRetries(1), WithFallbackToFile(true), WithOnDropped(func(Report, error) { dropped.Store(true) }))
+	report, _ := ri.AddGet("server down", nil, LevelError, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := ri.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := ri.ListReports()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].IssueID != report.IssueID {
+		t.Fatalf("report files %+v, want the one of IssueID %d", files, report.IssueID)
+	}
+	written, err := ReadReportFile(files[0].Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written.Description != "server down" {
+		t.Errorf("the file holds %q", written.Description)
+	}
+	if dropped.Load() {
+		t.Error("OnDropped called for a report written to file")
+	}
+}
+
+func TestRetryInFlightIsPending(t *testing.T) {
+	var requests atomic.Int32
+	retrying := make(chan struct{})
+	release := make(chan struct{})
+	ri := newLiveReporter(t, func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		close(retrying)
+		<-release
+	})
+	t.Cleanup(func() {
+		select {
+		case <-release:
+		default:
+			close(release)
+		}
+	})
+	ri.Add("retried", nil, LevelError, nil)
+
+	select {
+	case <-retrying:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the report was not retried")
+	}
+	if n := ri.pendingLen(); n != 1 {
+		t.Errorf("pendingLen() = %d during the retry, want 1", n)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := ri.Flush(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Flush() = %v during the retry, want %v", err, context.DeadlineExceeded)
+	}
+	close(release)
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := ri.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if n := ri.pendingLen(); n != 0 {
+		t.Errorf("pendingLen() = %d once delivered, want 0", n)
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	ri := newLiveReporter(t, func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}, WithRequestTimeout(50*time.Millisecond))
+	// Runs before the server is closed, which waits for the handler.
+	t.Cleanup(func() { close(release) })
+	start := time.Now()
+	err := ri.SubmitReport(context.Background(), Report{IssueID: 1})
+	if err == nil {
+		t.Fatal("SubmitReport to a hung server succeeded")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("SubmitReport returned after %s, want about 50ms", elapsed)
+	}
+}
+
+func TestSubmitReportCancelled(t *testing.T) {
+	release := make(chan struct{})
+	ri := newLiveReporter(t, func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	})
+	t.Cleanup(func() { close(release) })
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	start := time.Now()
+	err := ri.SubmitReport(ctx, Report{IssueID: 1})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("SubmitReport() = %v, want %v", err, context.Canceled)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("SubmitReport returned after %s, want right after the cancel", elapsed)
+	}
+}
+
+func TestAddContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ri := NewReportIssues("test", WithFolder(t.TempDir()))
+	if err := ri.AddContext(ctx, "cancelled", nil, LevelError, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("AddContext() = %v in file mode, want %v", err, context.Canceled)
+	}
+}
+
+func TestRequestTimeoutDefault(t *testing.T) {
+	for _, timeout := range []time.Duration{0, -time.Second} {
+		ri := NewReportIssues("test", WithRequestTimeout(timeout))
+		if got := ri.restyClient.GetClient().Timeout; got != defaultOptions.RequestTimeout {
+			t.Errorf("RequestTimeout %s: client timeout = %s, want %s", timeout, got, defaultOptions.RequestTimeout)
+		}
+	}
+}
+
+func TestAuthHeader(t *testing.T) {
+	tests := []struct {
+		name, key, header string
+		wantHeader        string
+		want              string
+	}{
+		{"bare key", "s3cret", "", "Authorization", "Bearer s3cret"},
+		{"with scheme", "Basic dXNlcjpwYXNz", "Authorization", "Authorization", "Basic dXNlcjpwYXNz"},
+		{"custom header", "s3cret", "X-API-Key", "X-API-Key", "s3cret"},
+		{"no key", "", "", "Authorization", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make(chan string, 1)
+			ri := newLiveReporter(t, func(w http.ResponseWriter, r *http.Request) {
+				got <- r.Header.Get(tt.wantHeader)
+			}, WithAPIKey(tt.key, tt.header))
+			if err := ri.SubmitReport(context.Background(), Report{IssueID: 1}); err != nil {
+				t.Fatal(err)
+			}
+			if value := <-got; value != tt.want {
+				t.Errorf("%s header = %q, want %q", tt.wantHeader, value, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompressRequests(t *testing.T) {
+	type request struct {
+		encoding string
+		report   Report
+	}
+	requests := make(chan request, 1)
+	ri := newLiveReporter(t, func(w http.ResponseWriter, r *http.Request) {
+		var body io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Errorf("reading the gzip body: %v", err)
+				requests <- request{}
+				return
+			}
+			body = zr
+		}
+		var submission ReportSubmission
+		if err := json.NewDecoder(body).Decode(&submission); err != nil {
+			t.Errorf("decoding the submission: %v", err)
+		}
+		requests <- request{r.Header.Get("Content-Encoding"), submission.Issue}
+	}, WithCompressRequests(true), WithCompressMinBytes(1024))
+
+	for _, tt := range []struct {
+		name     string
+		payload  string
+		encoding string
+	}{
+		{"small", "tiny", ""},
+		{"large", strings.Repeat("stack frame ", 500), "gzip"},
+	} {
+		report := Report{IssueID: 7, Description: "compressed", Extra: map[string]interface{}{"payload": tt.payload}}
+		if err := ri.SubmitReport(context.Background(), report); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		got := <-requests
+		if got.encoding != tt.encoding {
+			t.Errorf("%s: Content-Encoding = %q, want %q", tt.name, got.encoding, tt.encoding)
+		}
+		if got.report.IssueID != 7 || got.report.Extra["payload"] != tt.payload {
+			t.Errorf("%s: the server got %+v", tt.name, got.report)
+		}
+	}
+}
+
+// benchmarkBody returns the JSON body of a live submission of about 4 KB,
+// mostly stack frames and Extra, like the reports of a typical service.
+func benchmarkBody(b *testing.B) []byte {
+	b.Helper()
+	ri := NewReportIssues("billing-api", WithStackTraceDepth(32))
+	report, _, _ := ri.generate(context.Background(), "charging customer 4821: card declined by issuer", "", map[string]interface{}{
+		"customer_id": 4821,
+		"invoice_id":  "inv_2024_000193847",
+		"amount":      129.99,
+		"currency":    "EUR",
+		"gateway":     "stripe",
+		"attempt":     3,
+		"request_id":  "8f14e45f-ceea-467f-a8a4-7e1d2c5b9a3e",
+		"remote_addr": "10.12.4.77:53412",
+		"user_agent":  "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0 Safari/537.36",
+		"response":    `{"error":{"code":"card_declined","decline_code":"insufficient_funds","message":"Your card has insufficient funds."}}`,
+	}, string(LevelError), nil, reportOptions{})
+	for i := len(report.StackTrace); ; i++ {
+		body, err := json.Marshal(ReportSubmission{Issue: *report})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(body) >= 4<<10 {
+			return body
+		}
+		report.StackTrace = append(report.StackTrace, fmt.Sprintf("billing.(*Service).handler%d (service.go:%d)", i, 100+7*i))
+	}
+}
+
+// BenchmarkCompress posts a 4 KB report to a local server, gzipped or not,
+// reporting the bytes sent per request.
+func BenchmarkCompress(b *testing.B) {
+	body := benchmarkBody(b)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+	}))
+	defer srv.Close()
+	for _, bb := range []struct {
+		name     string
+		compress bool
+	}{
+		{"gzip", true},
+		{"identity", false},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			ri := NewReportIssues("billing-api", WithCompressRequests(bb.compress))
+			sent := len(body)
+			if bb.compress {
+				compressed, err := gzipBody(body)
+				if err != nil {
+					b.Fatal(err)
+				}
+				sent = len(compressed)
+			}
+			b.SetBytes(int64(len(body)))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := ri.post(context.Background(), srv.URL, body); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(sent), "wire-bytes/op")
+		})
+	}
+}
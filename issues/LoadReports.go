@@ -0,0 +1,48 @@
+package issues
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// LoadReports reads the *.coadmin_issue files of folder and returns the
+// reports sorted by T. Encrypted files are ignored, see ParseReportFile.
+// Files that cannot be read or parsed are skipped, their
+// errors are returned joined together along with the other reports.
+func LoadReports(folder string) ([]Report, error) {
+	files, err := filepath.Glob(filepath.Join(folder, "*"+ReportExt))
+	if err != nil {
+		return nil, err
+	}
+	var reports []Report
+	var errs []error
+	for _, file := range files {
+		report, err := ReadReportFile(file)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		reports = append(reports, report)
+	}
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].T < reports[j].T
+	})
+	return reports, errors.Join(errs...)
+}
+
+// ReadReportFile reads a report file written in file mode.
+func ReadReportFile(file string) (Report, error) {
+	var report Report
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return report, err
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return report, fmt.Errorf("parsing %s: %w", file, err)
+	}
+	return report, nil
+}
@@ -0,0 +1,40 @@
+package issues
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadReports(t *testing.T) {
+	ri := NewReportIssues("test", WithFolder(t.TempDir()))
+	want := map[string]Level{"disk full": LevelError, "cache miss": LevelWarning, "slow query": LevelInfo}
+	for issue, level := range want {
+		if !ri.Add(issue, map[string]interface{}{"issue": issue}, level, nil) {
+			t.Fatalf("Add(%q) failed", issue)
+		}
+	}
+	corrupt := filepath.Join(ri.Options.Folder, "corrupt.coadmin_issue")
+	if err := os.WriteFile(corrupt, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	reports, err := LoadReports(ri.Options.Folder)
+	if err == nil {
+		t.Error("LoadReports() did not report the corrupt file")
+	}
+	if len(reports) != len(want) {
+		t.Fatalf("LoadReports() returned %d reports, want %d", len(reports), len(want))
+	}
+	for i, report := range reports {
+		if level, ok := want[report.Description]; !ok || report.Level != string(level) {
+			t.Errorf("unexpected report %q at level %q", report.Description, report.Level)
+		}
+		if report.App != "test" || report.Extra["issue"] != report.Description {
+			t.Errorf("report %q read back as %+v", report.Description, report)
+		}
+		if i > 0 && report.T < reports[i-1].T {
+			t.Errorf("reports not sorted by T: %d before %d", reports[i-1].T, report.T)
+		}
+	}
+}
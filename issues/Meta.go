@@ -0,0 +1,79 @@
+package issues
+
+import (
+	"context"
+	"maps"
+	"sync"
+)
+
+// contextMetaFuncs are the extractors added by RegisterContextMeta.
+var (
+	contextMetaMu    sync.RWMutex
+	contextMetaFuncs []func(ctx context.Context) map[string]string
+)
+
+// RegisterContextMeta adds an extractor applied by every ReportIssues to the
+// context passed to AddWithContext and friends, before Options.ContextMeta.
+// It is meant to be called from init functions, e.g. by the issues/otel
+// package.
+func RegisterContextMeta(extract func(ctx context.Context) map[string]string) {
+	contextMetaMu.Lock()
+	defer contextMetaMu.Unlock()
+	contextMetaFuncs = append(contextMetaFuncs, extract)
+}
+
+// SetMeta sets a meta key sent with every subsequent report. Setting
+// "hostname" overrides the pre-populated hostname.
+func (ri *ReportIssues) SetMeta(key, value string) {
+	ri.Mutex.Lock()
+	defer ri.Mutex.Unlock()
+	ri.Meta[key] = value
+}
+
+// SetMetaMap sets several meta keys sent with every subsequent report.
+func (ri *ReportIssues) SetMetaMap(meta map[string]string) {
+	ri.Mutex.Lock()
+	defer ri.Mutex.Unlock()
+	for key, value := range meta {
+		ri.Meta[key] = value
+	}
+}
+
+// DeleteMeta removes a meta key from subsequent reports.
+func (ri *ReportIssues) DeleteMeta(key string) {
+	ri.Mutex.Lock()
+	defer ri.Mutex.Unlock()
+	delete(ri.Meta, key)
+}
+
+// contextMeta returns a snapshot of the meta map extended with the meta of
+// the Scope ctx comes from, then with the keys the registered extractors and
+// Options.ContextMeta find in ctx.
+func (ri *ReportIssues) contextMeta(ctx context.Context) map[string]string {
+	meta := ri.metaSnapshot()
+	if scoped, ok := ctx.Value(scopeMetaKey{}).(map[string]string); ok {
+		for key, value := range scoped {
+			meta[key] = value
+		}
+	}
+	contextMetaMu.RLock()
+	for _, extract := range contextMetaFuncs {
+		for key, value := range extract(ctx) {
+			meta[key] = value
+		}
+	}
+	contextMetaMu.RUnlock()
+	if ri.Options.ContextMeta != nil {
+		for key, value := range ri.Options.ContextMeta(ctx) {
+			meta[key] = value
+		}
+	}
+	return meta
+}
+
+// metaSnapshot returns a copy of the meta map, safe to attach to a report.
+func (ri *ReportIssues) metaSnapshot() map[string]string {
+	ri.Mutex.Lock()
+	defer ri.Mutex.Unlock()
+	return maps.Clone(ri.Meta)
+}
@@ -0,0 +1,67 @@
+package issues
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestMetaSetConcurrently(t *testing.T) {
+	ri := NewReportIssues("test", WithFolder(t.TempDir()))
+	stop := make(chan struct{})
+	var setter sync.WaitGroup
+	setter.Add(1)
+	go func() {
+		defer setter.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			ri.SetMeta("counter", strconv.Itoa(i))
+			ri.SetMetaMap(map[string]string{"even": strconv.FormatBool(i%2 == 0)})
+			ri.DeleteMeta("even")
+		}
+	}()
+
+	var reporters sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		reporters.Add(1)
+		go func(g int) {
+			defer reporters.Done()
+			for i := 0; i < 50; i++ {
+				report, _ := ri.AddGet(fmt.Sprintf("issue %d-%d", g, i), nil, LevelError, nil)
+				if report == nil {
+					t.Errorf("issue %d-%d was not reported", g, i)
+					return
+				}
+				if _, err := json.Marshal(report); err != nil {
+					t.Error(err)
+				}
+			}
+		}(g)
+	}
+	reporters.Wait()
+	close(stop)
+	setter.Wait()
+}
+
+func TestMetaSnapshot(t *testing.T) {
+	ri := NewReportIssues("test")
+	ri.SetMeta("deploy", "blue")
+	first, _, _ := ri.generate(context.Background(), "first", "", nil, "error", nil, reportOptions{})
+	ri.SetMeta("deploy", "green")
+	second, _, _ := ri.generate(context.Background(), "second", "", nil, "error", nil, reportOptions{})
+
+	if first.Meta["deploy"] != "blue" || second.Meta["deploy"] != "green" {
+		t.Errorf("deploy = %q and %q, want blue and green", first.Meta["deploy"], second.Meta["deploy"])
+	}
+	first.Meta["deploy"] = "red"
+	if second.Meta["deploy"] != "green" || ri.Meta["deploy"] != "green" {
+		t.Error("the reports share their Meta map")
+	}
+}
@@ -0,0 +1,135 @@
+package issues
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// File outputs, see Options.FileOutput.
+const (
+	FileOutputPerIssue = "per_issue"
+	FileOutputNDJSON   = "ndjson"
+)
+
+// NDJSONFilename is the file of Folder the reports are appended to with
+// FileOutputNDJSON.
+const NDJSONFilename = "reports.ndjson"
+
+// ErrNDJSONEncryption is returned when EncryptionKey is combined with
+// FileOutputNDJSON, which only holds plain JSON lines.
+var ErrNDJSONEncryption = errors.New("encryption is not supported with ndjson output")
+
+// writeNDJSON appends the report as a JSON line to the NDJSONFilename of
+// Folder, rotating it first when the line would make it exceed
+// NDJSONMaxSizeMB. The file lock makes concurrent writers, in this process
+// or others, safe.
+func (ri *ReportIssues) writeNDJSON(report *Report) error {
+	if ri.Options.EncryptionKey != nil {
+		return ErrNDJSONEncryption
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("marshalling report %d: %w", report.IssueID, err)
+	}
+	data = append(data, '\n')
+	if ri.Options.AutoCreateFolder && !ri.folderReady.Load() {
+		if err := os.MkdirAll(ri.Options.Folder, 0755); err != nil {
+			return fmt.Errorf("creating report folder: %w", err)
+		}
+		ri.folderReady.Store(true)
+	}
+	name := filepath.Join(ri.Options.Folder, NDJSONFilename)
+	if err := ri.appendNDJSON(name, data); err != nil {
+		ri.stats.failed.Add(1)
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("writing report %d: %w: %s", report.IssueID, ErrFolderMissing, ri.Options.Folder)
+		}
+		return fmt.Errorf("writing report %d: %w", report.IssueID, err)
+	}
+	ri.logIssue("written", report.id(), report.Level, "Report appended to file: %s", name)
+	return nil
+}
+
+// appendNDJSON appends data to name under the lock of name.lock.
+func (ri *ReportIssues) appendNDJSON(name string, data []byte) error {
+	mode := ri.Options.FileMode
+	if mode == 0 {
+		mode = defaultOptions.FileMode
+	}
+	lock, err := os.OpenFile(name+".lock", os.O_CREATE|os.O_RDWR, mode)
+	if err != nil {
+		return err
+	}
+	defer lock.Close()
+	if err := lockFile(lock); err != nil {
+		return fmt.Errorf("locking %s: %w", lock.Name(), err)
+	}
+	defer unlockFile(lock)
+
+	if maxSize := int64(ri.Options.NDJSONMaxSizeMB) << 20; maxSize > 0 {
+		if info, err := os.Stat(name); err == nil && info.Size() > 0 && info.Size()+int64(len(data)) > maxSize {
+			rotated := rotatedName(name, ri.now())
+			if err := os.Rename(name, rotated); err != nil {
+				return fmt.Errorf("rotating %s: %w", name, err)
+			}
+			ri.LogDebug("Rotated %s to %s", name, rotated)
+		}
+	}
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// rotatedName returns a free name for the NDJSONFilename name rotated at t.
+func rotatedName(name string, t time.Time) string {
+	base := filepath.Join(filepath.Dir(name), "reports."+t.UTC().Format("20060102T150405.000"))
+	rotated := base + ".ndjson"
+	for i := 1; ; i++ {
+		if _, err := os.Lstat(rotated); errors.Is(err, fs.ErrNotExist) {
+			return rotated
+		}
+		rotated = fmt.Sprintf("%s-%d.ndjson", base, i)
+	}
+}
+
+// ReadNDJSONFile reads the reports of a file written with FileOutputNDJSON.
+// Lines that cannot be parsed are skipped, their errors are returned joined
+// together along with the other reports.
+func ReadNDJSONFile(file string) ([]Report, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var reports []Report
+	var errs []error
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 64<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var report Report
+		if err := json.Unmarshal(scanner.Bytes(), &report); err != nil {
+			errs = append(errs, fmt.Errorf("parsing %s:%d: %w", file, line, err))
+			continue
+		}
+		reports = append(reports, report)
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return reports, errors.Join(errs...)
+}
@@ -0,0 +1,554 @@
+package issues
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// WithOptions applies the non-zero fields of the given Options struct on top
+// of the defaults. A zero field, e.g. a false AutoMeta or a zero
+// MinimumInterval, cannot be told from an unset one and keeps its default:
+// set zero values with the With* helpers applied after it, such as
+// WithAutoMeta(false) or WithMinimumInterval(0). A nil pointer keeps the
+// defaults.
+func WithOptions(options *Options) func(*Options) {
+	return func(o *Options) {
+		if options == nil {
+			return
+		}
+		src, dst := reflect.ValueOf(options).Elem(), reflect.ValueOf(o).Elem()
+		for i := 0; i < src.NumField(); i++ {
+			if field := src.Field(i); !field.IsZero() {
+				dst.Field(i).Set(field)
+			}
+		}
+	}
+}
+
+// WithLive enables or disables live mode.
+func WithLive(live bool) func(*Options) {
+	return func(o *Options) {
+		o.Live = live
+	}
+}
+
+// WithServer sets the server URL used in live mode.
+func WithServer(server string) func(*Options) {
+	return func(o *Options) {
+		o.Server = server
+	}
+}
+
+// WithServers sets several live endpoints with failover, see Options.Servers.
+func WithServers(strategy string, servers ...string) func(*Options) {
+	return func(o *Options) {
+		o.FailoverStrategy = strategy
+		o.Servers = servers
+	}
+}
+
+// WithFolder sets the folder reports are written to when not in live mode.
+func WithFolder(folder string) func(*Options) {
+	return func(o *Options) {
+		o.Folder = folder
+	}
+}
+
+// WithAutoCreateFolder creates the folder on the first write when missing.
+func WithAutoCreateFolder(create bool) func(*Options) {
+	return func(o *Options) {
+		o.AutoCreateFolder = create
+	}
+}
+
+// WithFolderTmp sets the folder report files are staged in before being
+// renamed into the folder.
+func WithFolderTmp(folder string) func(*Options) {
+	return func(o *Options) {
+		o.FolderTmp = folder
+	}
+}
+
+// WithFileMode sets the permission of report files.
+func WithFileMode(mode os.FileMode) func(*Options) {
+	return func(o *Options) {
+		o.FileMode = mode
+	}
+}
+
+// WithFilenameTemplate sets the template naming report files, see
+// Options.FilenameTemplate.
+func WithFilenameTemplate(tmpl string) func(*Options) {
+	return func(o *Options) {
+		o.FilenameTemplate = tmpl
+	}
+}
+
+// WithMinimumInterval sets the minimum interval between two reports of the same issue.
+func WithMinimumInterval(interval time.Duration) func(*Options) {
+	return func(o *Options) {
+		o.MinimumInterval = interval
+	}
+}
+
+// WithGroupByFormat makes printf-style methods throttle by format string.
+func WithGroupByFormat(group bool) func(*Options) {
+	return func(o *Options) {
+		o.GroupByFormat = group
+	}
+}
+
+// WithThrottleRetention sets how long issues are remembered once their
+// throttle interval has elapsed.
+func WithThrottleRetention(retention time.Duration) func(*Options) {
+	return func(o *Options) {
+		o.ThrottleRetention = retention
+	}
+}
+
+// WithThrottleStateFile persists the throttle state in file across restarts.
+func WithThrottleStateFile(file string) func(*Options) {
+	return func(o *Options) {
+		o.ThrottleStateFile = file
+	}
+}
+
+// WithMinLevel drops issues less severe than level.
+func WithMinLevel(level Level) func(*Options) {
+	return func(o *Options) {
+		o.MinLevel = level
+	}
+}
+
+// WithFallbackToErrorLevel reports issues with an unknown level as "error".
+func WithFallbackToErrorLevel(fallback bool) func(*Options) {
+	return func(o *Options) {
+		o.FallbackToErrorLevel = fallback
+	}
+}
+
+// WithLevelInterval overrides the minimum interval for a single level.
+func WithLevelInterval(level string, interval time.Duration) func(*Options) {
+	return func(o *Options) {
+		intervals := make(map[string]time.Duration, len(o.LevelIntervals)+1)
+		for l, i := range o.LevelIntervals {
+			intervals[l] = i
+		}
+		intervals[level] = interval
+		o.LevelIntervals = intervals
+	}
+}
+
+// WithLevelIntervals sets the per-level minimum intervals, replacing any
+// previously configured ones.
+func WithLevelIntervals(intervals map[string]time.Duration) func(*Options) {
+	return func(o *Options) {
+		o.LevelIntervals = make(map[string]time.Duration, len(intervals))
+		for level, interval := range intervals {
+			o.LevelIntervals[level] = interval
+		}
+	}
+}
+
+// WithDebug enables or disables debug logging.
+func WithDebug(debug bool) func(*Options) {
+	return func(o *Options) {
+		o.Debug = debug
+	}
+}
+
+// WithMeta adds meta keys sent with every report.
+func WithMeta(meta map[string]string) func(*Options) {
+	return func(o *Options) {
+		o.Meta = meta
+	}
+}
+
+// WithEnvironment sets the stage the application runs in, see
+// Options.Environment.
+func WithEnvironment(env string) func(*Options) {
+	return func(o *Options) {
+		o.Environment = env
+	}
+}
+
+// WithDefaultTags adds tags to every report, see Options.DefaultTags.
+func WithDefaultTags(tags ...string) func(*Options) {
+	return func(o *Options) {
+		o.DefaultTags = append(o.DefaultTags, tags...)
+	}
+}
+
+// WithAutoMeta enables or disables the runtime meta keys, see
+// Options.AutoMeta.
+func WithAutoMeta(enabled bool) func(*Options) {
+	return func(o *Options) {
+		o.AutoMeta = enabled
+	}
+}
+
+// WithContextMeta sets the function extracting meta from the context passed
+// to AddWithContext, see Options.ContextMeta.
+func WithContextMeta(extract func(ctx context.Context) map[string]string) func(*Options) {
+	return func(o *Options) {
+		o.ContextMeta = extract
+	}
+}
+
+// WithLogger routes debug messages to logger instead of stdout.
+func WithLogger(logger Logger) func(*Options) {
+	return func(o *Options) {
+		o.Logger = logger
+	}
+}
+
+// WithSlogLogger sends debug messages as structured records to logger.
+func WithSlogLogger(logger *slog.Logger) func(*Options) {
+	return func(o *Options) {
+		o.SlogLogger = logger
+	}
+}
+
+// WithOutput enables or disables output.
+func WithOutput(output bool) func(*Options) {
+	return func(o *Options) {
+		o.Output = output
+	}
+}
+
+// WithStackTraceDepth sets the maximum number of captured stack frames, zero disables capture.
+func WithStackTraceDepth(depth int) func(*Options) {
+	return func(o *Options) {
+		o.StackTraceDepth = depth
+	}
+}
+
+// WithStackTraceSkip sets how many extra caller frames are skipped.
+func WithStackTraceSkip(skip int) func(*Options) {
+	return func(o *Options) {
+		o.StackTraceSkip = skip
+	}
+}
+
+// WithCallerSkip sets how many extra frames are skipped when filling the Caller field.
+func WithCallerSkip(skip int) func(*Options) {
+	return func(o *Options) {
+		o.CallerSkip = skip
+	}
+}
+
+// WithBatchSize sets the maximum number of reports sent per request in live mode.
+func WithBatchSize(size int) func(*Options) {
+	return func(o *Options) {
+		o.BatchSize = size
+	}
+}
+
+// WithBulkServer sets the URL bulk submissions are posted to.
+func WithBulkServer(server string) func(*Options) {
+	return func(o *Options) {
+		o.BulkServer = server
+	}
+}
+
+// WithMaxRetries sets how many times a failed live submission is retried.
+func WithMaxRetries(retries int) func(*Options) {
+	return func(o *Options) {
+		o.MaxRetries = retries
+	}
+}
+
+// WithRetryBaseInterval sets the delay before the first retry.
+func WithRetryBaseInterval(interval time.Duration) func(*Options) {
+	return func(o *Options) {
+		o.RetryBaseInterval = interval
+	}
+}
+
+// WithRetryableStatusCodes sets the HTTP status codes of a failed submission
+// that are retried, see Options.RetryableStatusCodes.
+func WithRetryableStatusCodes(codes ...int) func(*Options) {
+	return func(o *Options) {
+		o.RetryableStatusCodes = codes
+	}
+}
+
+// WithMaxRetryAfter caps the Retry-After delay honored by the live worker,
+// see Options.MaxRetryAfter.
+func WithMaxRetryAfter(limit time.Duration) func(*Options) {
+	return func(o *Options) {
+		o.MaxRetryAfter = limit
+	}
+}
+
+// WithOnDropped sets the callback for reports dropped after MaxRetries
+// attempts or rejected by the server.
+func WithOnDropped(onDropped func(Report, error)) func(*Options) {
+	return func(o *Options) {
+		o.OnDropped = onDropped
+	}
+}
+
+// WithCircuitBreaker opens the circuit after threshold consecutive failed
+// submissions for timeout, zero threshold disables the circuit breaker.
+func WithCircuitBreaker(threshold int, timeout time.Duration) func(*Options) {
+	return func(o *Options) {
+		o.CircuitBreakerThreshold = threshold
+		o.CircuitBreakerTimeout = timeout
+	}
+}
+
+// WithOnCircuitStateChange sets the callback notified of circuit transitions.
+func WithOnCircuitStateChange(fn func(state string)) func(*Options) {
+	return func(o *Options) {
+		o.OnCircuitStateChange = fn
+	}
+}
+
+// WithFallbackToFile writes reports that exhausted their retries into the folder.
+func WithFallbackToFile(fallback bool) func(*Options) {
+	return func(o *Options) {
+		o.FallbackToFile = fallback
+	}
+}
+
+// WithMaxBufferSize caps the number of reports waiting in the live buffer.
+func WithMaxBufferSize(size int) func(*Options) {
+	return func(o *Options) {
+		o.MaxBufferSize = size
+	}
+}
+
+// WithBufferOverflow sets the strategy applied when the live buffer is full.
+func WithBufferOverflow(strategy string, blockTimeout time.Duration) func(*Options) {
+	return func(o *Options) {
+		o.BufferOverflow = strategy
+		o.BufferBlockTimeout = blockTimeout
+	}
+}
+
+// WithOnBufferFull sets the callback invoked on buffer overflow events.
+func WithOnBufferFull(onBufferFull func(Report)) func(*Options) {
+	return func(o *Options) {
+		o.OnBufferFull = onBufferFull
+	}
+}
+
+// WithBufferChanSize sets the capacity of the live buffer channel.
+//
+// Deprecated: use WithMaxBufferSize.
+func WithBufferChanSize(size int) func(*Options) {
+	return func(o *Options) {
+		o.BufferChanSize = size
+	}
+}
+
+// WithRestyClient sets the Resty client used for live submissions, e.g. one
+// pointed at a test server or with a custom transport.
+func WithRestyClient(client *resty.Client) func(*Options) {
+	return func(o *Options) {
+		o.HTTPClient = client
+	}
+}
+
+// WithHashExtraKeys includes the values of the given Extra keys in the
+// IssueID and throttle hash.
+func WithHashExtraKeys(keys ...string) func(*Options) {
+	return func(o *Options) {
+		o.HashExtraKeys = keys
+	}
+}
+
+// WithHashAlgorithm selects the hash identifying issues, HashCRC32 or HashFNV64.
+func WithHashAlgorithm(algorithm string) func(*Options) {
+	return func(o *Options) {
+		o.HashAlgorithm = algorithm
+	}
+}
+
+// WithIDHasher sets the function computing IssueIDs, see Options.IDHasher.
+func WithIDHasher(hasher func(input string) uint32) func(*Options) {
+	return func(o *Options) {
+		o.IDHasher = hasher
+	}
+}
+
+// WithHMACSecret signs live submissions with secret, see Options.HMACSecret.
+func WithHMACSecret(secret []byte) func(*Options) {
+	return func(o *Options) {
+		o.HMACSecret = secret
+	}
+}
+
+// WithMaxReportBytes caps the size of a marshalled report, see
+// Options.MaxReportBytes.
+func WithMaxReportBytes(n int) func(*Options) {
+	return func(o *Options) {
+		o.MaxReportBytes = n
+	}
+}
+
+// WithEncryptionKey encrypts the report files with the 32 bytes key, see
+// Options.EncryptionKey.
+func WithEncryptionKey(key []byte) func(*Options) {
+	return func(o *Options) {
+		o.EncryptionKey = key
+	}
+}
+
+// WithRedactKeys replaces the glob patterns of the Extra keys to redact, see
+// Options.RedactKeys. Passing none disables the key based redaction.
+func WithRedactKeys(patterns ...string) func(*Options) {
+	return func(o *Options) {
+		o.RedactKeys = patterns
+	}
+}
+
+// WithRedactor adds a Redactor, see Options.Redactors.
+func WithRedactor(redactor Redactor) func(*Options) {
+	return func(o *Options) {
+		o.Redactors = append(o.Redactors, redactor)
+	}
+}
+
+// WithBackend delivers the live submissions through backend, see
+// Options.Backend.
+func WithBackend(backend Backend) func(*Options) {
+	return func(o *Options) {
+		o.Backend = backend
+	}
+}
+
+// WithEncoding sets the encoding of the HTTP submissions, see
+// Options.Encoding.
+func WithEncoding(encoding string) func(*Options) {
+	return func(o *Options) {
+		o.Encoding = encoding
+	}
+}
+
+// WithCompressRequests gzips the HTTP request bodies, see
+// Options.CompressRequests.
+func WithCompressRequests(compress bool) func(*Options) {
+	return func(o *Options) {
+		o.CompressRequests = compress
+	}
+}
+
+// WithCompressMinBytes sets the size above which request bodies are gzipped,
+// see Options.CompressMinBytes.
+func WithCompressMinBytes(size int) func(*Options) {
+	return func(o *Options) {
+		o.CompressMinBytes = size
+	}
+}
+
+// WithNDJSONOutput appends every report to a single NDJSON file, rotated at
+// maxSizeMB, instead of writing one file per issue, see Options.FileOutput.
+func WithNDJSONOutput(maxSizeMB int) func(*Options) {
+	return func(o *Options) {
+		o.FileOutput = FileOutputNDJSON
+		o.NDJSONMaxSizeMB = maxSizeMB
+	}
+}
+
+// WithBeforeSend sets the hook filtering or modifying the reports, see
+// Options.BeforeSend.
+func WithBeforeSend(hook func(*Report) *Report) func(*Options) {
+	return func(o *Options) {
+		o.BeforeSend = hook
+	}
+}
+
+// WithDeleteAfterReplay deletes the files replayed by ReplayFromFiles once
+// submitted, see Options.DeleteAfterReplay.
+func WithDeleteAfterReplay(delete bool) func(*Options) {
+	return func(o *Options) {
+		o.DeleteAfterReplay = delete
+	}
+}
+
+// WithDeliveryCallbacks sets the callbacks observing the live deliveries,
+// see Options.OnSubmitted and Options.OnFailed. Either may be nil.
+func WithDeliveryCallbacks(onSubmitted func(Report, int), onFailed func(Report, error)) func(*Options) {
+	return func(o *Options) {
+		o.OnSubmitted = onSubmitted
+		o.OnFailed = onFailed
+	}
+}
+
+// WithPersistBuffer saves the unsent reports on Close and sends them again
+// after a restart, see Options.PersistBuffer.
+func WithPersistBuffer(persist bool) func(*Options) {
+	return func(o *Options) {
+		o.PersistBuffer = persist
+	}
+}
+
+// WithCaptureEnvVars adds the named environment variables to the meta of
+// every report, see Options.CaptureEnvVars.
+func WithCaptureEnvVars(names ...string) func(*Options) {
+	return func(o *Options) {
+		o.CaptureEnvVars = append(o.CaptureEnvVars, names...)
+	}
+}
+
+// WithCaptureAllEnv adds the whole environment to the meta of every report,
+// see Options.CaptureAllEnv.
+func WithCaptureAllEnv(capture bool) func(*Options) {
+	return func(o *Options) {
+		o.CaptureAllEnv = capture
+	}
+}
+
+// WithEnvRedactPatterns adds regular expressions of the environment
+// variables whose value is redacted, see Options.EnvRedactPatterns.
+func WithEnvRedactPatterns(patterns ...string) func(*Options) {
+	return func(o *Options) {
+		o.EnvRedactPatterns = append(o.EnvRedactPatterns, patterns...)
+	}
+}
+
+// WithClock replaces the real clock, e.g. with one a test advances manually,
+// see Options.Clock.
+func WithClock(clock Clock) func(*Options) {
+	return func(o *Options) {
+		o.Clock = clock
+	}
+}
+
+// WithPollInterval sets how often WaitQueue and ReplayFromFiles check the
+// buffer, see Options.PollInterval.
+func WithPollInterval(interval time.Duration) func(*Options) {
+	return func(o *Options) {
+		o.PollInterval = interval
+	}
+}
+
+// WithRequestTimeout bounds every live submission request.
+func WithRequestTimeout(timeout time.Duration) func(*Options) {
+	return func(o *Options) {
+		o.RequestTimeout = timeout
+	}
+}
+
+// WithRepanicAfterReport resumes recovered panics once they are reported.
+func WithRepanicAfterReport(repanic bool) func(*Options) {
+	return func(o *Options) {
+		o.RepanicAfterReport = repanic
+	}
+}
+
+// WithAPIKey authenticates live submissions with key, sent in the given
+// header, or as a Bearer token in the Authorization header when header is empty.
+func WithAPIKey(key, header string) func(*Options) {
+	return func(o *Options) {
+		o.APIKey = key
+		o.AuthHeader = header
+	}
+}
@@ -0,0 +1,54 @@
+package issues
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWithOptionsKeepsDefaults(t *testing.T) {
+	ri := NewReportIssues("test", WithOptions(&Options{
+		Folder:          "/tmp/reports",
+		MinimumInterval: time.Minute,
+	}))
+	if ri.Options.Folder != "/tmp/reports" || ri.Options.MinimumInterval != time.Minute {
+		t.Errorf("set fields not applied: %+v", ri.Options)
+	}
+	if !slices.Equal(ri.Options.RedactKeys, DefaultRedactKeys) {
+		t.Errorf("RedactKeys = %v, want the defaults", ri.Options.RedactKeys)
+	}
+	if !slices.Equal(ri.Options.RetryableStatusCodes, DefaultRetryableStatusCodes) {
+		t.Errorf("RetryableStatusCodes = %v, want the defaults", ri.Options.RetryableStatusCodes)
+	}
+	if ri.Options.MaxReportBytes != defaultOptions.MaxReportBytes ||
+		ri.Options.StackTraceDepth != defaultOptions.StackTraceDepth ||
+		!ri.Options.AutoMeta || !ri.Options.AutoCreateFolder {
+		t.Errorf("defaults lost: %+v", ri.Options)
+	}
+}
+
+func TestWithOptionsThenHelpers(t *testing.T) {
+	ri := NewReportIssues("test",
+		WithOptions(&Options{Folder: "/tmp/reports"}),
+		WithAutoCreateFolder(false),
+		WithFolder("/tmp/other"),
+	)
+	if ri.Options.AutoCreateFolder || ri.Options.Folder != "/tmp/other" {
+		t.Errorf("helpers applied after WithOptions were ignored: %+v", ri.Options)
+	}
+}
+
+func TestWithOptionsZeroValues(t *testing.T) {
+	ri := NewReportIssues("test", WithOptions(&Options{MinimumInterval: 0, AutoMeta: false}))
+	if ri.Options.MinimumInterval != defaultOptions.MinimumInterval || !ri.Options.AutoMeta {
+		t.Errorf("zero fields did not keep their defaults: MinimumInterval %s, AutoMeta %v", ri.Options.MinimumInterval, ri.Options.AutoMeta)
+	}
+	ri = NewReportIssues("test",
+		WithOptions(&Options{Folder: "/tmp/reports"}),
+		WithMinimumInterval(0),
+		WithAutoMeta(false),
+	)
+	if ri.Options.MinimumInterval != 0 || ri.Options.AutoMeta {
+		t.Errorf("helpers did not set the zero values: MinimumInterval %s, AutoMeta %v", ri.Options.MinimumInterval, ri.Options.AutoMeta)
+	}
+}
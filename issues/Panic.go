@@ -0,0 +1,146 @@
+package issues
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+)
+
+// RecoverAndReport recovers a panic and reports it synchronously with the
+// given level, meant to be deferred at the top of goroutines:
+//
+//	defer ri.RecoverAndReport(issues.LevelFatal)
+//
+// The stack trace of the panicking goroutine is recorded in full. In live
+// mode the report bypasses the buffer and is sent right away. When
+// Options.RepanicAfterReport is set the panic is resumed once reported.
+func (ri *ReportIssues) RecoverAndReport(level Level) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	ri.reportPanic(r, parsePanicStack(debug.Stack()), level, nil)
+	if ri.Options.RepanicAfterReport {
+		panic(r)
+	}
+}
+
+// Recover returns a function recovering a panic and reporting it with
+// "fatal" level, like RecoverAndReport, along with extra:
+//
+//	defer ri.Recover(nil)()
+//
+// The panicking stack, limited to StackTraceDepth frames, is also added to
+// extra["stack"]. The returned function does nothing when there is no panic.
+func (ri *ReportIssues) Recover(extra map[string]interface{}) func() {
+	return func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		trace := parsePanicStack(debug.Stack())
+		merged := make(map[string]interface{}, len(extra)+1)
+		for key, value := range extra {
+			merged[key] = value
+		}
+		if depth := ri.Options.StackTraceDepth; depth > 0 {
+			merged["stack"] = trace[:min(depth, len(trace))]
+		}
+		ri.reportPanic(r, trace, LevelFatal, merged)
+		if ri.Options.RepanicAfterReport {
+			panic(r)
+		}
+	}
+}
+
+// Go runs fn in a new goroutine, reporting any panic with "fatal" level.
+func (ri *ReportIssues) Go(fn func()) {
+	go func() {
+		defer ri.RecoverAndReport(LevelFatal)
+		fn()
+	}()
+}
+
+// reportPanic reports the recovered value r along with the parsed stack
+// trace and extra, which is modified.
+func (ri *ReportIssues) reportPanic(r interface{}, trace []string, level Level, extra map[string]interface{}) {
+	if ri.IsDisabled() {
+		return
+	}
+	if extra == nil {
+		extra = make(map[string]interface{})
+	}
+	extra["panic"] = fmt.Sprint(r)
+	normalized, err := ri.normalizeLevel(level)
+	if err != nil {
+		ri.LogDebug("RecoverAndReport: %v", err)
+		return
+	}
+	if ri.belowMinLevel(normalized) {
+		return
+	}
+	report, key, _ := ri.generate(context.Background(), fmt.Sprintf("panic: %v", r), "", extra, normalized, nil, reportOptions{})
+	if report == nil {
+		return
+	}
+	report.StackTrace = trace
+	if len(report.StackTrace) > 0 {
+		report.Caller = report.StackTrace[0]
+	}
+	if report = ri.prepare(report, key); report == nil {
+		return
+	}
+
+	if !ri.Options.Live {
+		if err := ri.writeFile(report); err != nil {
+			ri.LogDebug("RecoverAndReport: %v", err)
+		}
+		return
+	}
+	if _, err := ri.submit(context.Background(), []Report{*report}); err != nil {
+		ri.LogDebug("RecoverAndReport: sending failed, buffering instead: %v", err)
+		if err := ri.enqueue(context.Background(), *report); err != nil {
+			ri.LogDebug("RecoverAndReport: %v", err)
+		}
+	}
+}
+
+// parsePanicStack turns the output of debug.Stack, taken while recovering,
+// into frames formatted like formatFrame, starting at the panicking function.
+func parsePanicStack(stack []byte) []string {
+	lines := strings.Split(strings.TrimSpace(string(stack)), "\n")
+	var trace []string
+	for i := 1; i+1 < len(lines); i += 2 {
+		function := strings.TrimSpace(lines[i])
+		location := strings.TrimSpace(lines[i+1])
+		if strings.HasPrefix(function, "panic(") {
+			// Everything so far is the recovering code, start over.
+			trace = trace[:0]
+			continue
+		}
+		prefix := ""
+		if strings.HasPrefix(function, "created by ") {
+			prefix, function = "created by ", strings.TrimPrefix(function, "created by ")
+		} else if p := strings.LastIndex(function, "("); p > 0 {
+			function = function[:p]
+		}
+		if strings.HasPrefix(function, libPackagePrefix) {
+			continue
+		}
+		if s := strings.LastIndex(function, "/"); s >= 0 {
+			function = function[s+1:]
+		}
+		function = prefix + function
+		if p := strings.LastIndex(location, " +0x"); p > 0 {
+			location = location[:p]
+		}
+		if len(trace) == 0 && strings.HasPrefix(function, "runtime.") {
+			// Runtime frames raising the panic, e.g. runtime.panicmem.
+			continue
+		}
+		trace = append(trace, fmt.Sprintf("%s (%s)", function, filepath.Base(location)))
+	}
+	return trace
+}
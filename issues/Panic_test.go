@@ -0,0 +1,42 @@
+package issues
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRecoverTruncates(t *testing.T) {
+	const limit = 2048
+	ri := NewReportIssues("test", WithFolder(t.TempDir()), WithMaxReportBytes(limit))
+	func() {
+		defer ri.Recover(map[string]interface{}{"payload": strings.Repeat("x", 10*limit)})()
+		panic("boom")
+	}()
+
+	files, err := ri.ListReports()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("got %d reports, want 1", len(files))
+	}
+	data, err := os.ReadFile(files[0].Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) > limit {
+		t.Errorf("report is %d bytes, want at most %d", len(data), limit)
+	}
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Meta[MetaTruncated] != "true" {
+		t.Errorf("Meta[%q] = %q, want \"true\"", MetaTruncated, report.Meta[MetaTruncated])
+	}
+	if report.Extra["panic"] != "boom" {
+		t.Errorf("Extra[\"panic\"] = %v, want \"boom\"", report.Extra["panic"])
+	}
+}
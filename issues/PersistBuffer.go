@@ -0,0 +1,86 @@
+package issues
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// BufferFile is the file of Folder where Close saves the unsent reports when
+// Options.PersistBuffer is set, with the EncryptedExt suffix when
+// Options.EncryptionKey is set.
+const BufferFile = "buffer.json"
+
+// bufferFile returns the path of the persisted buffer.
+func (ri *ReportIssues) bufferFile() string {
+	file := filepath.Join(ri.Options.Folder, BufferFile)
+	if ri.Options.EncryptionKey != nil {
+		file += EncryptedExt
+	}
+	return file
+}
+
+// persistBuffer saves the reports Close could not send to the BufferFile,
+// atomically, replacing a previous one.
+func (ri *ReportIssues) persistBuffer(reports []Report) error {
+	data, err := json.Marshal(reports)
+	if err != nil {
+		return fmt.Errorf("marshalling buffer: %w", err)
+	}
+	if ri.Options.EncryptionKey != nil {
+		if data, err = encrypt(data, ri.Options.EncryptionKey); err != nil {
+			return fmt.Errorf("encrypting buffer: %w", err)
+		}
+	}
+	if ri.Options.AutoCreateFolder {
+		if err := os.MkdirAll(ri.Options.Folder, 0755); err != nil {
+			return fmt.Errorf("creating report folder: %w", err)
+		}
+	}
+	if err := writeFileAtomic(ri.Options.Folder, ri.bufferFile(), data, 0600); err != nil {
+		return fmt.Errorf("writing buffer: %w", err)
+	}
+	return nil
+}
+
+// loadBuffer enqueues the reports saved by persistBuffer and removes the
+// BufferFile. A missing file is ignored, a corrupt one is logged and removed
+// so it does not come back on every start.
+func (ri *ReportIssues) loadBuffer() {
+	file := ri.bufferFile()
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			ri.LogDebug("Ignoring persisted buffer: %v", err)
+		}
+		return
+	}
+	if err := os.Remove(file); err != nil {
+		// Keep the reports out of the buffer rather than sending them twice.
+		ri.LogDebug("Ignoring persisted buffer: %v", err)
+		return
+	}
+	if ri.Options.EncryptionKey != nil {
+		if data, err = decrypt(data, ri.Options.EncryptionKey); err != nil {
+			ri.LogDebug("Ignoring corrupt persisted buffer %s: %v", file, err)
+			return
+		}
+	}
+	var reports []Report
+	if err := json.Unmarshal(data, &reports); err != nil {
+		ri.LogDebug("Ignoring corrupt persisted buffer %s: %v", file, err)
+		return
+	}
+	loaded := 0
+	for _, report := range reports {
+		if err := ri.enqueue(ri.ctx, report); err != nil {
+			ri.LogDebug("Dropping persisted report %d: %v", report.IssueID, err)
+			continue
+		}
+		loaded++
+	}
+	ri.LogDebug("Loaded %d buffered reports from %s", loaded, file)
+}
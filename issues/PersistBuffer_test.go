@@ -0,0 +1,69 @@
+package issues
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPersistBufferAcrossRestart(t *testing.T) {
+	var up atomic.Bool
+	var mu sync.Mutex
+	var received []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !up.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var submission ReportSubmission
+		if err := json.NewDecoder(r.Body).Decode(&submission); err != nil {
+			t.Errorf("decoding the submission: %v", err)
+		}
+		mu.Lock()
+		received = append(received, submission.Issue.Description)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+	folder := t.TempDir()
+	options := []func(*Options){
+		WithLive(true),
+		WithServer(srv.URL),
+		WithFolder(folder),
+		WithPersistBuffer(true),
+		WithRetryBaseInterval(10 * time.Millisecond),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ri := NewReportIssues("test", options...)
+	ri.Add("disk full", nil, LevelError, nil)
+	ri.Add("cache miss", nil, LevelWarning, nil)
+	ri.Close(ctx)
+	if _, err := os.Stat(ri.bufferFile()); err != nil {
+		t.Fatalf("the buffer was not persisted: %v", err)
+	}
+
+	// Restart with the server back up.
+	up.Store(true)
+	ri = NewReportIssues("test", options...)
+	defer ri.Close(ctx)
+	if _, err := os.Stat(ri.bufferFile()); !os.IsNotExist(err) {
+		t.Errorf("the persisted buffer was not removed once loaded: %v", err)
+	}
+	if err := ri.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	sort.Strings(received)
+	if len(received) != 2 || received[0] != "cache miss" || received[1] != "disk full" {
+		t.Errorf("server received %q after the restart, want both reports", received)
+	}
+}
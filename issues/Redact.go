@@ -0,0 +1,81 @@
+package issues
+
+import (
+	"path"
+	"strings"
+)
+
+// Redacted replaces the Extra values matched by Options.RedactKeys or
+// Options.Redactors.
+const Redacted = "[REDACTED]"
+
+// DefaultRedactKeys is the default value of Options.RedactKeys.
+var DefaultRedactKeys = []string{
+	"*password*",
+	"*passwd*",
+	"*secret*",
+	"*token*",
+	"*api_key*",
+	"*apikey*",
+	"authorization",
+	"cookie",
+	"set-cookie",
+}
+
+// Redactor decides whether the Extra value stored under key must be
+// replaced, returning the replacement and true in that case.
+type Redactor func(key string, value interface{}) (interface{}, bool)
+
+// redact replaces in place the values of extra, a copy owned by the report,
+// whose key matches Options.RedactKeys or which a Redactor rewrites.
+// Nested maps and slices are walked recursively.
+func (ri *ReportIssues) redact(extra map[string]interface{}) map[string]interface{} {
+	if len(ri.Options.RedactKeys) == 0 && len(ri.Options.Redactors) == 0 {
+		return extra
+	}
+	for key, value := range extra {
+		extra[key] = ri.redactValue(key, value)
+	}
+	return extra
+}
+
+// redactValue returns the redacted value stored under key.
+func (ri *ReportIssues) redactValue(key string, value interface{}) interface{} {
+	if ri.redactKey(key) {
+		return Redacted
+	}
+	for _, redactor := range ri.Options.Redactors {
+		if replacement, ok := redactor(key, value); ok {
+			return replacement
+		}
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return ri.redact(v)
+	case map[string]string:
+		for k := range v {
+			if replaced, ok := ri.redactValue(k, v[k]).(string); ok {
+				v[k] = replaced
+			} else {
+				v[k] = Redacted
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = ri.redactValue(key, v[i])
+		}
+	}
+	return value
+}
+
+// redactKey reports whether key matches one of the Options.RedactKeys glob
+// patterns, case-insensitively.
+func (ri *ReportIssues) redactKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, pattern := range ri.Options.RedactKeys {
+		if ok, _ := path.Match(strings.ToLower(pattern), key); ok {
+			return true
+		}
+	}
+	return false
+}
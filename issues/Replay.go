@@ -0,0 +1,96 @@
+package issues
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrNotLive is returned by the methods requiring Options.Live.
+var ErrNotLive = errors.New("live mode is not enabled")
+
+// ReplayFromFiles queues the reports stored in the *.coadmin_issue files of
+// Folder into the live buffer, e.g. those written during an outage before
+// switching to live mode. Files encrypted with Options.EncryptionKey are
+// replayed too when it is set. The reports bypass throttling and
+// BeforeSend. Once the buffer is full it waits for the live worker to make
+// room, whatever the BufferOverflow strategy, until ctx is done. With
+// Options.DeleteAfterReplay a file is deleted once its report was
+// submitted. It returns the number of reports queued; files that cannot be
+// read are skipped, their errors are returned joined together.
+func (ri *ReportIssues) ReplayFromFiles(ctx context.Context) (int, error) {
+	if !ri.Options.Live {
+		return 0, ErrNotLive
+	}
+	files, err := filepath.Glob(filepath.Join(ri.Options.Folder, "*"+ReportExt))
+	if err != nil {
+		return 0, err
+	}
+	if ri.Options.EncryptionKey != nil {
+		encrypted, err := filepath.Glob(filepath.Join(ri.Options.Folder, "*"+ReportExt+EncryptedExt))
+		if err != nil {
+			return 0, err
+		}
+		files = append(files, encrypted...)
+	}
+	queued := 0
+	var errs []error
+	for _, file := range files {
+		report, err := ParseReportFile(file, ri.Options.EncryptionKey)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if ri.Options.DeleteAfterReplay {
+			report.replayFile = file
+		}
+		if err := ri.enqueueWait(ctx, *report); err != nil {
+			return queued, errors.Join(append(errs, err)...)
+		}
+		queued++
+	}
+	ri.LogDebug("Replayed %d reports from %s", queued, ri.Options.Folder)
+	return queued, errors.Join(errs...)
+}
+
+// enqueueWait sends a report to the live buffer, waking the live worker up
+// while the buffer is full, until ctx is done.
+func (ri *ReportIssues) enqueueWait(ctx context.Context, report Report) error {
+	ticker := time.NewTicker(ri.pollInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case ri.buffer <- report:
+			return nil
+		default:
+		}
+		select {
+		case ri.wake <- struct{}{}:
+		default:
+		}
+		select {
+		case ri.buffer <- report:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ri.workerDone:
+			return ErrClosed
+		case <-ticker.C:
+		}
+	}
+}
+
+// removeReplayed deletes the files of the replayed reports of batch, once
+// submitted.
+func (ri *ReportIssues) removeReplayed(batch []Report) {
+	for _, report := range batch {
+		if report.replayFile == "" {
+			continue
+		}
+		if err := os.Remove(report.replayFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+			ri.LogDebug("Deleting replayed report: %v", err)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package issues
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// ReportError reports a Go error. The outermost message becomes the
+// description, every message of the errors.Unwrap chain is recorded in
+// Extra["error_chain"], and the fields of well-known typed errors
+// (*os.PathError, *net.OpError, syscall.Errno) are added to Extra.
+// The throttle hash is computed from the error types of the chain and the
+// root-cause message, so transient values such as paths or ports do not
+// create distinct issues.
+func (ri *ReportIssues) ReportError(err error, level Level, extra map[string]interface{}) bool {
+	return ri.reportError(context.Background(), err, level, extra)
+}
+
+// reportError implements ReportError, see AddContext for ctx.
+func (ri *ReportIssues) reportError(ctx context.Context, err error, level Level, extra map[string]interface{}) bool {
+	if err == nil {
+		return false
+	}
+	fields := make(map[string]interface{}, len(extra)+4)
+	for k, v := range extra {
+		fields[k] = v
+	}
+
+	var chain, types []string
+	root := err
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		root = e
+		chain = append(chain, e.Error())
+		types = append(types, fmt.Sprintf("%T", e))
+		switch typed := e.(type) {
+		case *os.PathError:
+			fields["op"] = typed.Op
+			fields["path"] = typed.Path
+		case *net.OpError:
+			fields["op"] = typed.Op
+			fields["net"] = typed.Net
+			if typed.Addr != nil {
+				fields["addr"] = typed.Addr.String()
+			}
+		case syscall.Errno:
+			fields["errno"] = int(typed)
+		}
+	}
+	fields["error_chain"] = chain
+	fields["error_type"] = types[0]
+
+	groupKey := strings.Join(types, ">") + ": " + root.Error()
+	result := ri.addResult(ctx, err.Error(), groupKey, fields, level, nil)
+	if result.Err != nil {
+		ri.LogDebug("ReportError: %v", result.Err)
+	}
+	return result.err() == nil
+}
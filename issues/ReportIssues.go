@@ -1,13 +1,22 @@
 package issues
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"hash/crc32"
+	"io"
+	"io/fs"
+	"log/slog"
+	"maps"
 	"os"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fatih/color"
@@ -15,15 +24,282 @@ import (
 	"github.com/sanity-io/litter"
 )
 
+// Logger receives the debug messages of a ReportIssues, see Options.Logger.
+type Logger interface {
+	Debugf(format string, args ...any)
+}
+
 // Options defines configuration options for ReportIssues.
 type Options struct {
 	Live   bool
 	Folder string
 	Server string
+	// Servers replaces Server with several live endpoints: a submission that
+	// fails with a network error or a 5xx response is retried on the next
+	// one, see FailoverStrategy.
+	Servers []string
+	// FailoverStrategy is FailoverPrimaryFirst (default) or
+	// FailoverRoundRobin.
+	FailoverStrategy string
+	// AutoCreateFolder creates Folder (and FolderTmp) with mode 0755 on the
+	// first write when missing. It defaults to true; when disabled a missing
+	// folder fails the write with ErrFolderMissing. Mkdir errors are returned
+	// by AddE and friends, never printed.
+	AutoCreateFolder bool
+	// FolderTmp is where report files are staged before being renamed into
+	// Folder, Folder itself when empty. It must be on the same filesystem.
+	FolderTmp string
+	// FileMode is the permission of report files, zero means the default of
+	// 0644. Use 0600 when extra data must not be readable by other users.
+	FileMode os.FileMode
+	// FilenameTemplate names report files using the {id}, {level}, {app} and
+	// {timestamp} (Unix milliseconds) placeholders, "{id}.coadmin_issue"
+	// when empty. Include {timestamp} to keep every occurrence of an issue.
+	// It must end with ReportExt for the files to be listed and replayed.
+	// An invalid template makes every file write fail, see
+	// ValidateFilenameTemplate.
+	FilenameTemplate string
+	// FileOutput is FileOutputPerIssue (default), writing one file per
+	// issue, or FileOutputNDJSON, appending every report as a JSON line to
+	// the NDJSONFilename of Folder.
+	FileOutput string
+	// NDJSONMaxSizeMB rotates the NDJSONFilename once it would exceed this
+	// size, zero or negative never rotates it.
+	NDJSONMaxSizeMB int
+	// PersistBuffer makes Close save the reports it could not send to the
+	// BufferFile, and NewReportIssues enqueue them again in live mode.
+	PersistBuffer bool
+	// DeleteAfterReplay deletes the files replayed by ReplayFromFiles once
+	// their report was submitted.
+	DeleteAfterReplay bool
 
 	MinimumInterval time.Duration
 	Output          bool
 	Debug           bool
+	// Meta is added to the meta sent with every report, next to the
+	// hostname. It is copied by NewReportIssues, use SetMeta afterwards.
+	Meta map[string]string
+	// Environment is the stage the application runs in, e.g. "prod" or
+	// "staging", sent in the "env" meta key and part of the IssueID so the
+	// same issue is throttled per environment. It defaults to the
+	// EnvironmentEnv variable.
+	Environment string
+	// DefaultTags are added to the Tags of every report.
+	DefaultTags []string
+	// AutoMeta adds the "go_version", "os", "arch", "pid" and "executable"
+	// keys to Meta, unless Meta already sets them. They are computed once by
+	// NewReportIssues.
+	AutoMeta bool
+	// ContextMeta extracts meta from the context passed to AddWithContext
+	// and the other context-aware methods, e.g. trace and span IDs. The
+	// returned keys are added to the report's meta.
+	ContextMeta func(ctx context.Context) map[string]string
+	// Logger receives the debug messages when Debug is set, instead of
+	// colored lines on stdout.
+	Logger Logger
+	// SlogLogger receives the debug messages as structured records at debug
+	// level, taking precedence over Logger. Debug is not required: the
+	// handler decides what is recorded. Events about a single issue carry
+	// app, issue_id, level and action attributes, e.g.
+	//
+	//	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	//	ri := issues.NewReportIssues("myapp", issues.WithSlogLogger(logger))
+	SlogLogger *slog.Logger
+
+	// HashAlgorithm selects the hash identifying issues: HashCRC32 (default)
+	// or HashFNV64, which makes collisions unlikely. 64-bit hashes are sent
+	// in Report.IssueID64, IssueID then holds their lower 32 bits.
+	HashAlgorithm string
+	// IDHasher computes the IssueID from the lowercased app, level and issue
+	// text, CRC32 when nil. It must be deterministic and cheap; FNV32Hasher
+	// and SHA256Hasher are ready-made alternatives.
+	IDHasher func(input string) uint32
+
+	// HashExtraKeys lists Extra keys whose values are part of the IssueID and
+	// throttle hash, so e.g. the same failure for different customers is
+	// reported once per customer. Missing keys hash as empty.
+	HashExtraKeys []string
+
+	// GroupByFormat makes the printf-style methods (Errorf, ...) compute the
+	// throttle hash from the format string instead of the rendered message,
+	// so varying arguments do not defeat throttling.
+	GroupByFormat bool
+
+	// MinLevel drops issues less severe than this level before throttling,
+	// empty reports every level. Change it at runtime with SetMinLevel.
+	MinLevel Level
+	// FallbackToErrorLevel reports issues with an unknown level as "error"
+	// instead of rejecting them with ErrInvalidLevel.
+	FallbackToErrorLevel bool
+
+	// LevelIntervals overrides MinimumInterval per level, e.g.
+	// {"fatal": 0, "warning": 5 * time.Minute}. A zero interval disables
+	// throttling for that level, unlisted levels use MinimumInterval. Levels
+	// of the same issue text are throttled independently.
+	LevelIntervals map[string]time.Duration
+	// ThrottleRetention is how long an issue is remembered once its interval
+	// has elapsed. Older entries are swept from the throttle state at most
+	// once per ThrottleRetention (and at least a second apart) while issues
+	// are added, along with their suppressed count.
+	ThrottleRetention time.Duration
+	// ThrottleStateFile persists the throttle state across restarts when
+	// set: it is loaded by NewReportIssues, dropping expired entries, and
+	// saved atomically shortly after every change and on Shutdown. A corrupt
+	// file is ignored. Suppressed counts are not persisted.
+	ThrottleStateFile string
+
+	// StackTraceDepth is the maximum number of frames recorded per report.
+	// Zero disables stack trace capture entirely.
+	StackTraceDepth int
+	// StackTraceSkip drops additional frames above the first caller outside
+	// this package, for applications wrapping the reporter in helpers.
+	StackTraceSkip int
+	// CallerSkip is the equivalent of StackTraceSkip for the Caller field.
+	CallerSkip int
+	// RepanicAfterReport resumes panics recovered by RecoverAndReport once
+	// they have been reported.
+	RepanicAfterReport bool
+
+	// BatchSize is the maximum number of reports sent per HTTP request in
+	// live mode. Above one, reports are posted as a BulkReportSubmission.
+	BatchSize int
+	// BulkServer is the URL bulk submissions are posted to, Server if empty.
+	BulkServer string
+
+	// MaxRetries is how many times a failed live submission is retried.
+	MaxRetries int
+	// RetryBaseInterval is the delay before the first retry, doubled on
+	// every further attempt.
+	RetryBaseInterval time.Duration
+	// RetryableStatusCodes are the HTTP status codes of a failed submission
+	// that are retried. Any other 4xx or 5xx response drops the reports
+	// right away, e.g. a 400 for a report the server will never accept.
+	// Nil means DefaultRetryableStatusCodes, an empty list retries none.
+	RetryableStatusCodes []int
+	// MaxRetryAfter caps the delay the live worker pauses for when the server
+	// answers 429 or 503 with a Retry-After header, 5 minutes by default.
+	MaxRetryAfter time.Duration
+	// OnDropped is called with reports discarded after MaxRetries attempts,
+	// or rejected by the server, see RetryableStatusCodes.
+	OnDropped func(Report, error)
+	// CircuitBreakerThreshold is the number of consecutive failed
+	// submissions opening the circuit: the live worker then stops sending,
+	// keeping reports buffered, for CircuitBreakerTimeout before probing the
+	// server again. Zero disables the circuit breaker.
+	CircuitBreakerThreshold int
+	CircuitBreakerTimeout   time.Duration
+	// OnSubmitted is called after each successful delivery with a copy of
+	// every report delivered and the HTTP status code, zero with a custom
+	// Backend. OnFailed is called after each failed attempt. Both run
+	// outside of the Mutex, their panics are recovered.
+	OnSubmitted func(report Report, statusCode int)
+	OnFailed    func(report Report, err error)
+	// OnCircuitOpen is called whenever the circuit opens.
+	OnCircuitOpen func()
+	// OnCircuitStateChange is called on every transition of the circuit
+	// with the new state, see GetCircuitState.
+	OnCircuitStateChange func(state string)
+	// FallbackToFile writes reports that exhausted MaxRetries into Folder
+	// instead of dropping them.
+	FallbackToFile bool
+
+	// HTTPClient is the Resty client used for live submissions, a fresh one
+	// is created when nil. The library still sets the Content-Type header on
+	// every request it builds with it.
+	HTTPClient *resty.Client
+	// APIKey authenticates live submissions, sent in the AuthHeader header.
+	APIKey string
+	// AuthHeader is the header carrying APIKey, "Authorization" when empty,
+	// in which case a key without scheme is sent as "Bearer <key>".
+	AuthHeader string
+	// HMACSecret signs live submissions when set: the HMAC-SHA256 of the
+	// request body is sent in the SignatureHeader, see Sign and
+	// VerifySignature.
+	HMACSecret []byte
+	// CompressRequests gzips the HTTP request bodies, sent with
+	// Content-Encoding: gzip. The signature of HMACSecret covers the
+	// uncompressed body. Compression trades CPU time per request for fewer
+	// bytes on the wire, which pays off on metered or slow links rather than
+	// on a local network; BenchmarkCompress compares both for a 4 KB report.
+	CompressRequests bool
+	// CompressMinBytes leaves the bodies up to this size uncompressed, gzip
+	// gains little on them.
+	CompressMinBytes int
+	// Clock replaces time.Now for the throttling, the report timestamps and
+	// the retry and circuit breaker schedules, see Clock. Nil means the
+	// real clock.
+	Clock Clock
+	// PollInterval is how often WaitQueue and ReplayFromFiles check the
+	// buffer, 100 milliseconds by default.
+	PollInterval time.Duration
+	// RequestTimeout bounds every live submission request, zero means the
+	// default of 10 seconds. It is not applied to an injected HTTPClient.
+	RequestTimeout time.Duration
+	// Encoding of the HTTP submissions, EncodingJSON when empty or
+	// EncodingProtobuf for the messages of the issues/proto package, sent
+	// as application/x-protobuf.
+	Encoding string
+	// Backend delivers the live submissions instead of the HTTP backend
+	// when set, e.g. the issues/grpc package. Server, Servers, BulkServer,
+	// HTTPClient, APIKey and HMACSecret only apply to the HTTP backend.
+	Backend Backend
+
+	// MaxReportBytes caps the size of a marshalled report, see truncate.
+	// Oversized reports are truncated rather than dropped. Zero or negative
+	// disables the limit.
+	MaxReportBytes int
+	// BeforeSend is called with every report about to be buffered or
+	// written, after throttling. It may modify the report or return another
+	// one; returning nil drops it, without marking the issue as reported.
+	// A panic in BeforeSend is recovered and the report is kept.
+	BeforeSend func(*Report) *Report
+	// MaxDescriptionLength caps, in characters, the description of the
+	// reports once normalized; the original is then kept in
+	// Extra["full_description"]. Zero or negative disables the cap.
+	MaxDescriptionLength int
+
+	// RedactKeys are case-insensitive glob patterns, see path.Match, of the
+	// Extra keys whose values are replaced by Redacted, at any depth of the
+	// nested maps and slices. It defaults to DefaultRedactKeys.
+	RedactKeys []string
+	// Redactors are applied in order to every Extra value not matched by
+	// RedactKeys, the first one returning true replaces the value.
+	Redactors []Redactor
+	// CaptureEnvVars are the environment variables added to the meta of
+	// every report as "env_NAME", see EnvPrefix. CaptureAllEnv captures the
+	// whole environment instead.
+	CaptureEnvVars []string
+	CaptureAllEnv  bool
+	// EnvRedactPatterns are regular expressions, e.g. ".*SECRET.*", matched
+	// against the names of the captured variables whose value is replaced
+	// with Redacted. RedactKeys apply to the names as well.
+	EnvRedactPatterns []string
+	// EnvInExtra adds the captured variables to Extra instead of Meta.
+	EnvInExtra bool
+
+	// EncryptionKey encrypts the report files with AES-256-GCM when set, it
+	// must be 32 bytes long. Encrypted files get the EncryptedExt suffix, see
+	// DecryptReportFile and ParseReportFile.
+	EncryptionKey []byte
+
+	// BufferChanSize is the capacity of the live buffer channel, overriding
+	// MaxBufferSize when positive.
+	//
+	// Deprecated: use MaxBufferSize.
+	BufferChanSize int
+	// MaxBufferSize caps the number of reports waiting in the live buffer.
+	MaxBufferSize int
+	// BufferOverflow is the strategy applied when the buffer is full, one of
+	// BufferOverflowDropOldest, BufferOverflowDropNewest or BufferOverflowBlock.
+	// Any other strategy makes every live Add fail, see
+	// ValidateBufferOverflow.
+	BufferOverflow string
+	// BufferBlockTimeout bounds how long Add waits for space with the block
+	// strategy before giving up, zero waits indefinitely.
+	BufferBlockTimeout time.Duration
+	// OnBufferFull is called with the evicted or rejected report whenever
+	// the buffer overflows.
+	OnBufferFull func(Report)
 }
 
 // defaultOptions defines the default configuration.
@@ -34,60 +310,199 @@ var defaultOptions = Options{
 	MinimumInterval: 60 * time.Second,
 	Output:          false,
 	Debug:           false,
+	StackTraceDepth: 10,
+	StackTraceSkip:  0,
+	CallerSkip:      0,
+	BatchSize:       1,
+	BulkServer:      "",
+
+	AutoCreateFolder: true,
+	FileMode:         0644,
+	FileOutput:       FileOutputPerIssue,
+	NDJSONMaxSizeMB:  100,
+
+	ThrottleRetention: 60 * time.Second,
+
+	MaxRetries:           3,
+	RetryBaseInterval:    500 * time.Millisecond,
+	RetryableStatusCodes: DefaultRetryableStatusCodes,
+	MaxRetryAfter:        5 * time.Minute,
+
+	CircuitBreakerThreshold: 5,
+	CircuitBreakerTimeout:   30 * time.Second,
+
+	AuthHeader:     "Authorization",
+	RequestTimeout: 10 * time.Second,
+	Encoding:       EncodingJSON,
+
+	CompressMinBytes: 1024,
+
+	MaxReportBytes: 256 << 10,
+	RedactKeys:     DefaultRedactKeys,
+
+	MaxDescriptionLength: 1000,
+
+	AutoMeta: true,
+
+	MaxBufferSize:      10000,
+	BufferOverflow:     BufferOverflowDropOldest,
+	BufferBlockTimeout: 5 * time.Second,
+
+	Clock:        realClock{},
+	PollInterval: 100 * time.Millisecond,
 }
 
 type ReportSubmission struct {
 	Issue Report `json:"issue"`
 }
 
+// BulkReportSubmission is the payload posted when BatchSize is above one.
+type BulkReportSubmission struct {
+	Issues []Report `json:"issues"`
+}
+
 // Report represents a generated issue report.
 type Report struct {
 	Version     int                    `json:"v"`
 	IssueID     uint32                 `json:"issue_id"`
+	IssueID64   uint64                 `json:"issue_id64,omitempty"` // with a 64-bit HashAlgorithm
 	Meta        map[string]string      `json:"meta"`
 	Options     map[string]interface{} `json:"options"`
 	Caller      string                 `json:"caller"`
-	StackTrace  []string               `json:"stackTrace"` // Not implemented for now.
+	StackTrace  []string               `json:"stackTrace"`
 	App         string                 `json:"app"`
 	Extra       map[string]interface{} `json:"extra"`
 	Description string                 `json:"description"`
 	Level       string                 `json:"level"`
 	LibVersion  string                 `json:"libversion"`
 	T           int64                  `json:"t"`
+
+	// SuppressedCount is the number of occurrences throttled since the
+	// previous report of this issue, the first of them at FirstSuppressedAt
+	// (Unix milliseconds).
+	SuppressedCount   int   `json:"suppressed_count,omitempty"`
+	FirstSuppressedAt int64 `json:"first_suppressed_at,omitempty"`
+
+	// Tags are free-form labels the server indexes, lowercased and
+	// deduplicated, see Options.DefaultTags and OptionTags.
+	Tags []string `json:"tags,omitempty"`
+
+	replayFile string // file deleted once submitted, see ReplayFromFiles
+}
+
+// id returns the full IssueID of the report, IssueID64 when set.
+func (r *Report) id() uint64 {
+	if r.IssueID64 != 0 {
+		return r.IssueID64
+	}
+	return uint64(r.IssueID)
+}
+
+// setSuppressed records the occurrences suppressed before this report.
+func (r *Report) setSuppressed(entry throttleEntry) {
+	if entry.suppressed > 0 {
+		r.SuppressedCount = entry.suppressed
+		r.FirstSuppressedAt = entry.firstSuppressed.UnixMilli()
+	}
 }
 
 // ReportIssues provides methods to generate and report issues.
+// Meta is sent with every report and is pre-populated with the "hostname"
+// key, the runtime keys of Options.AutoMeta and the "env" key of
+// Options.Environment; treat it as read-only and change it with SetMeta,
+// SetMetaMap and DeleteMeta, which are safe for concurrent use. Reports take
+// a snapshot of it under the Mutex.
 type ReportIssues struct {
 	AppName     string
 	Options     Options
-	reported    map[uint32]time.Time // stores next allowed reporting time per issue hash
+	reported    map[throttleKey]throttleEntry // stores next allowed reporting time per issue
 	Meta        map[string]string
-	Buffer      []Report
-	Mutex       sync.Mutex    // protects reported map and Buffer
+	buffer      chan Report   // reports waiting for the live worker
+	inflight    atomic.Int32  // reports taken from the buffer but not yet sent
+	serverIndex atomic.Int32  // index of the last healthy entry of servers()
+	folderReady atomic.Bool   // set once the folders are known to exist
+	disabled    atomic.Bool   // see Disable
+	stats       counters      // see GetStats
+	retries     []retryEntry  // failed live submissions waiting for a retry
+	nextSweep   time.Time     // next cleanup of the reported map
+	saveTimer   *time.Timer   // pending save of the ThrottleStateFile
+	saveMu      sync.Mutex    // serializes the saves of the ThrottleStateFile
+	templateErr error         // set when Options.FilenameTemplate is invalid
+	overflowErr error         // set when Options.BufferOverflow is invalid
+	Mutex       sync.Mutex    // protects reported map, Meta and retries
 	restyClient *resty.Client // Resty client for HTTP requests
+
+	wake         chan struct{}   // wakes the live worker up, see Flush
+	flushWaiters []chan struct{} // closed once the buffer is empty, protected by Mutex
+	done         chan struct{}   // closed to stop the live worker
+	workerDone   chan struct{}   // closed once the live worker has returned
+	ctx          context.Context // lifecycle of the live worker's requests
+	cancel       context.CancelFunc
+	circuit      circuitBreaker // protected by Mutex
+	pausedUntil  time.Time      // Retry-After of the server, protected by Mutex
+	workerOnce   sync.Once      // starts the live worker, see startWorker
+	shutdownOnce sync.Once
+	closeOnce    sync.Once
+
+	envRedact []*regexp.Regexp // compiled Options.EnvRedactPatterns
 }
 
 // NewReportIssues creates a new ReportIssues instance.
-func NewReportIssues(appName string, options *Options) *ReportIssues {
+// Options are applied in order on top of the defaults, see the With* helpers.
+func NewReportIssues(appName string, options ...func(*Options)) *ReportIssues {
 	opts := defaultOptions
-	if options != nil {
+	for _, option := range options {
 		// Override defaults with provided options.
-		opts = *options
+		option(&opts)
 	}
 	ri := &ReportIssues{
 		AppName:  strings.ToLower(appName),
 		Options:  opts,
-		reported: make(map[uint32]time.Time),
+		reported: make(map[throttleKey]throttleEntry),
 		Meta: map[string]string{
 			"hostname": getHostname(),
 		},
-		Buffer:      []Report{},
-		restyClient: resty.New(),
+		buffer:      make(chan Report, bufferSize(opts)),
+		restyClient: opts.HTTPClient,
+		wake:        make(chan struct{}, 1),
+		done:        make(chan struct{}),
+		workerDone:  make(chan struct{}),
 	}
-	if ri.Options.Live {
+	ri.ctx, ri.cancel = context.WithCancel(context.Background())
+	ri.disabled.Store(disabledByEnv())
+	if opts.AutoMeta {
+		maps.Copy(ri.Meta, runtimeMeta())
+	}
+	ri.resolveEnvironment()
+	for key, value := range opts.Meta {
+		ri.Meta[key] = value
+	}
+	if ri.Options.ThrottleStateFile != "" {
+		ri.loadThrottleState()
+	}
+	ri.compileEnvRedactPatterns()
+	if err := ValidateFilenameTemplate(ri.Options.FilenameTemplate); err != nil {
+		ri.LogDebug("%v", err)
+		ri.templateErr = err
+	}
+	if err := ValidateBufferOverflow(ri.Options.BufferOverflow); err != nil {
+		ri.LogDebug("%v", err)
+		ri.overflowErr = err
+	}
+	if ri.restyClient == nil {
+		timeout := ri.Options.RequestTimeout
+		if timeout <= 0 {
+			timeout = defaultOptions.RequestTimeout
+		}
+		ri.restyClient = resty.New().SetTimeout(timeout)
+	}
+	if ri.Options.Live && !ri.IsDisabled() {
 		ri.LogDebug("Initialized Resty client for HTTP requests")
 		// Start live worker in a separate goroutine.
-		go ri.liveWorker()
+		ri.startWorker()
+		if ri.Options.PersistBuffer {
+			ri.loadBuffer()
+		}
 	}
 	return ri
 }
@@ -101,165 +516,597 @@ func getHostname() string {
 	return h
 }
 
-// generate creates a Report based on the given parameters.
-// For now, we skip detailed stack trace generation.
-func (ri *ReportIssues) generate(issue string, extra map[string]interface{}, level string, options map[string]interface{}) *Report {
+// runtimeMeta returns the meta added by Options.AutoMeta.
+func runtimeMeta() map[string]string {
+	return map[string]string{
+		"go_version": runtime.Version(),
+		"os":         runtime.GOOS,
+		"arch":       runtime.GOARCH,
+		"pid":        strconv.Itoa(os.Getpid()),
+		"executable": executableName(),
+	}
+}
+
+// executableName returns the base name of the running executable.
+func executableName() string {
+	path, err := os.Executable()
+	if err != nil {
+		if len(os.Args) == 0 {
+			return "unknown"
+		}
+		path = os.Args[0]
+	}
+	return filepath.Base(path)
+}
+
+// reportVersion is the version of the Report wire format.
+const reportVersion = 8
+
+// generate creates a Report based on the given parameters. The issue is
+// normalized first, see normalizeDescription. The throttle hash is computed
+// from the fingerprint in ro when set, then groupKey, then the normalized
+// issue; ro may also override the throttle interval.
+// It returns the report and its throttle key, or nil and the next allowed
+// reporting time when the issue is throttled.
+func (ri *ReportIssues) generate(ctx context.Context, issue, groupKey string, extra map[string]interface{}, level string, options map[string]interface{}, ro reportOptions) (*Report, throttleKey, time.Time) {
+	original := issue
+	issue, cut := ri.normalizeDescription(issue)
+	if ro.fingerprint != "" {
+		groupKey = ro.fingerprint
+	}
+	if groupKey == "" {
+		groupKey = issue
+	}
 	// Compute a hash to throttle duplicate issues.
-	hashInput := strings.ToLower(fmt.Sprintf("%s_issue_%s_%s", ri.AppName, level, issue))
-	hash := crc32.ChecksumIEEE([]byte(hashInput))
+	hash := ri.groupHash(groupKey, level, extra)
 	ri.LogDebug("Generated hash %d for issue '%s' (app: %s, level: %s)", hash, issue, ri.AppName, level)
 
-	now := time.Now()
-	ri.Mutex.Lock()
-	nextAllowed, exists := ri.reported[hash]
-	if exists && now.Before(nextAllowed) {
-		ri.LogDebug("Issue '%s' for app '%s' reported too recently; skipping generation.", issue, ri.AppName)
-		ri.Mutex.Unlock()
-		return nil // Issue reported too recently.
+	interval := ri.interval(level)
+	if ro.hasInterval {
+		interval = ro.interval
 	}
-	// Set next allowed reporting time.
-	ri.reported[hash] = now.Add(ri.Options.MinimumInterval)
-	ri.Mutex.Unlock()
-
-	// Caller information is not implemented; use placeholder.
-	caller := "not_implemented"
-	// Use unknown libVersion for now.
-	libVersion := "unknown"
+	now := ri.now()
+	throttled, entry := ri.throttle(hash, level, interval, now)
+	if throttled {
+		ri.logIssue("throttled", hash, level, "Issue '%s' for app '%s' reported too recently; skipping generation.", issue, ri.AppName)
+		return nil, throttleKey{}, entry.nextAllowed // Issue reported too recently.
+	}
+	ri.stats.countAdded(level)
 
 	report := Report{
-		Version:     5,
-		IssueID:     hash,
-		Meta:        ri.Meta,
-		Options:     options,
-		Caller:      caller,
-		StackTrace:  []string{}, // Not implemented.
+		Version:     reportVersion,
+		Meta:        ri.contextMeta(ctx),
+		Options:     copyMap(options),
+		Caller:      captureCaller(ri.Options.CallerSkip),
+		StackTrace:  captureStackTrace(ri.Options.StackTraceDepth, ri.Options.StackTraceSkip),
 		App:         ri.AppName,
 		Extra:       extra,
 		Description: issue,
 		Level:       level,
-		LibVersion:  libVersion,
+		LibVersion:  Version(),
 		T:           now.UnixMilli(),
+		Tags:        normalizeTags(ri.Options.DefaultTags, ro.tags),
+	}
+	ri.setIssueID(&report, hash)
+	ri.setExtra(&report)
+	ri.addEnv(&report)
+	if cut {
+		keepFullDescription(&report, original)
 	}
+	report.setSuppressed(entry)
 	if ri.Options.Debug {
 		ri.LogDebug("Report: %s", litter.Sdump(report))
 	}
-	return &report
+	return &report, throttleKey{hash: hash, level: level}, time.Time{}
 }
 
 // WaitQueue will wait for a maximum time or until the buffer is flushed.
 func (ri *ReportIssues) WaitQueue(maxWait time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), maxWait)
+	defer cancel()
+	return ri.WaitQueueContext(ctx)
+}
+
+// WaitQueueContext waits until the buffer is flushed or ctx is done.
+func (ri *ReportIssues) WaitQueueContext(ctx context.Context) bool {
+	if ri.IsDisabled() {
+		return true
+	}
 	ri.LogDebug("Waiting for queue to be flushed")
-	timeout := time.After(maxWait)
-	ticker := time.NewTicker(100 * time.Millisecond)
+	ticker := time.NewTicker(ri.pollInterval())
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-timeout:
+		case <-ctx.Done():
 			ri.LogDebug("waitQueue: Timeout reached, exiting wait.")
 			return false
 		case <-ticker.C:
-			ri.Mutex.Lock()
-			if len(ri.Buffer) == 0 {
-				ri.Mutex.Unlock()
+			if ri.pendingLen() == 0 {
 				ri.LogDebug("waitQueue: Buffer is empty, exiting wait.")
 				return true
 			}
-			ri.Mutex.Unlock()
 		}
 	}
 }
 
 // Add creates and outputs a report.
 // In live mode, the report is buffered; otherwise, it is written to a file.
-func (ri *ReportIssues) Add(issue string, extra map[string]interface{}, level string, options map[string]interface{}) bool {
-	report := ri.generate(issue, extra, level, options)
+// options is sent along with the report, except for the keys interpreted by
+// the library such as OptionInterval and OptionFingerprint. extra and options
+// are copied, they may be modified once Add returns.
+func (ri *ReportIssues) Add(issue string, extra map[string]interface{}, level Level, options map[string]interface{}) bool {
+	return ri.AddWithContext(context.Background(), issue, extra, level, options)
+}
+
+// AddString behaves like Add with a level given as a raw string, e.g. read
+// from a configuration, which is parsed as by ParseLevel.
+func (ri *ReportIssues) AddString(issue string, extra map[string]interface{}, level string, options map[string]interface{}) bool {
+	return ri.Add(issue, extra, Level(level), options)
+}
+
+// AddWithContext behaves like Add but gives up once ctx is done, see
+// AddContext, and adds the meta found by Options.ContextMeta in ctx.
+func (ri *ReportIssues) AddWithContext(ctx context.Context, issue string, extra map[string]interface{}, level Level, options map[string]interface{}) bool {
+	err := ri.AddContext(ctx, issue, extra, level, options)
+	if err != nil && !errors.Is(err, ErrThrottled) && !errors.Is(err, ErrFiltered) && !errors.Is(err, ErrDropped) {
+		ri.LogDebug("Add: %v", err)
+	}
+	return err == nil
+}
+
+// AddE behaves like Add but returns an error instead of false: ErrThrottled
+// when the issue was suppressed by MinimumInterval, or the wrapped marshalling
+// or writing failure. In live mode the HTTP submission happens later in the
+// live worker, so its failures are not reported here.
+func (ri *ReportIssues) AddE(issue string, extra map[string]interface{}, level Level, options map[string]interface{}) error {
+	return ri.AddContext(context.Background(), issue, extra, level, options)
+}
+
+// AddContext behaves like AddE but gives up once ctx is done: while waiting
+// for space in a full live buffer, or before writing the report file. An
+// issue aborted this way is not marked as reported, so it can be retried.
+// In live mode ctx only governs the enqueue step: the live worker sends the
+// report later under its own context, which is cancelled by Shutdown.
+func (ri *ReportIssues) AddContext(ctx context.Context, issue string, extra map[string]interface{}, level Level, options map[string]interface{}) error {
+	result := ri.addResult(ctx, issue, "", extra, level, options)
+	return result.err()
+}
+
+// AddWithFingerprint behaves like Add but computes the IssueID and throttle
+// hash from fingerprint instead of the issue text, see OptionFingerprint.
+func (ri *ReportIssues) AddWithFingerprint(fingerprint, issue string, extra map[string]interface{}, level Level, options map[string]interface{}) bool {
+	result := ri.addResult(context.Background(), issue, fingerprint, extra, level, options)
+	if result.Err != nil {
+		ri.LogDebug("Add: %v", result.Err)
+	}
+	return result.err() == nil
+}
+
+// AddResult creates and outputs a report and tells what happened to it:
+// throttled, buffered for the live worker, written to a file or failed.
+func (ri *ReportIssues) AddResult(issue string, extra map[string]interface{}, level Level, options map[string]interface{}) Result {
+	return ri.addResult(context.Background(), issue, "", extra, level, options)
+}
+
+// AddGet behaves like Add but also returns a copy of the generated report,
+// e.g. to log its IssueID. The report is nil when the issue was throttled,
+// filtered or dropped; mutating it does not affect the buffered one.
+func (ri *ReportIssues) AddGet(issue string, extra map[string]interface{}, level Level, options map[string]interface{}) (*Report, bool) {
+	result := ri.addResult(context.Background(), issue, "", extra, level, options)
+	if result.Err != nil {
+		ri.LogDebug("Add: %v", result.Err)
+	}
+	if result.Report == nil {
+		return nil, result.err() == nil
+	}
+	report := result.Report.clone()
+	return &report, result.err() == nil
+}
+
+// addResult implements AddResult and AddContext, see generate for groupKey.
+func (ri *ReportIssues) addResult(ctx context.Context, issue, groupKey string, extra map[string]interface{}, level Level, options map[string]interface{}) Result {
+	if ri.IsDisabled() {
+		return Result{Reason: ResultDisabled}
+	}
+	if err := ctx.Err(); err != nil {
+		return Result{Reason: ResultError, Err: err}
+	}
+	normalized, err := ri.normalizeLevel(level)
+	if err != nil {
+		return Result{Reason: ResultError, Err: err}
+	}
+	if ri.belowMinLevel(normalized) {
+		return Result{Reason: ResultFiltered}
+	}
+	ro, options, err := parseReportOptions(options)
+	if err != nil {
+		return Result{Reason: ResultError, Err: err}
+	}
+	report, key, nextAllowed := ri.generate(ctx, issue, groupKey, extra, normalized, options, ro)
 
 	if report == nil {
+		return Result{Reason: ResultThrottled, NextAllowed: nextAllowed}
+	}
+	result := ri.output(ctx, report, key)
+	if result.Err != nil && ctx.Err() != nil && errors.Is(result.Err, ctx.Err()) {
+		// Aborted by the caller, let the issue be reported again.
+		ri.unthrottle(key)
+	}
+	return result
+}
+
+// AddReport pushes a pre-built report through the same throttling and
+// live/file pipeline as Add. The throttle hash is computed from the report's
+// App, Level and Description, or the OptionFingerprint in its Options; App,
+// IssueID, Meta, LibVersion, Version and T are filled in when left empty.
+func (ri *ReportIssues) AddReport(r Report) bool {
+	if ri.IsDisabled() {
+		return true
+	}
+	if r.App == "" {
+		r.App = ri.AppName
+	}
+	level, err := ri.normalizeLevel(Level(r.Level))
+	if err != nil {
+		ri.LogDebug("AddReport: %v", err)
 		return false
 	}
-	if ri.Options.Live {
-		ri.Mutex.Lock()
-		ri.Buffer = append(ri.Buffer, *report)
-		ri.Mutex.Unlock()
-		ri.LogDebug("Report added to live buffer: IssueID %d - total buffer size: %d", report.IssueID, len(ri.Buffer))
+	r.Level = level
+	if ri.belowMinLevel(level) {
+		return false
+	}
+	ro, options, err := parseReportOptions(r.Options)
+	if err != nil {
+		ri.LogDebug("AddReport: %v", err)
+		return false
+	}
+	r.Options = copyMap(options)
+	original := r.Description
+	description, cut := ri.normalizeDescription(r.Description)
+	r.Description = description
+	interval := ri.interval(level)
+	if ro.hasInterval {
+		interval = ro.interval
+	}
+	groupKey := r.Description
+	if ro.fingerprint != "" {
+		groupKey = ro.fingerprint
+	}
+	hash := ri.issueHash(r.App, r.Level, ri.withHashExtra(groupKey, r.Extra))
+	now := ri.now()
+	throttled, entry := ri.throttle(hash, r.Level, interval, now)
+	if throttled {
+		ri.logIssue("throttled", hash, r.Level, "Report '%s' for app '%s' reported too recently; skipping.", r.Description, r.App)
+		return false
+	}
+	ri.stats.countAdded(r.Level)
+	if r.IssueID == 0 && r.IssueID64 == 0 {
+		ri.setIssueID(&r, hash)
+	}
+	if r.T == 0 {
+		r.T = now.UnixMilli()
+	}
+	if r.Meta == nil {
+		r.Meta = ri.metaSnapshot()
 	} else {
-		fileName := fmt.Sprintf("%d.coadmin_issue", report.IssueID)
-		fullFilename := filepath.Join(ri.Options.Folder, fileName)
-		data, err := json.Marshal(report)
-		if err != nil {
-			fmt.Printf("Error marshalling report: %v\n", err)
-			return false
-		}
-		err = os.WriteFile(fullFilename, data, 0644)
-		if err != nil {
-			fmt.Printf("Error writing report file: %v\n", err)
-			return false
-		}
-		ri.LogDebug("Report written to file: %s", fullFilename)
+		r.Meta = maps.Clone(r.Meta)
+	}
+	if r.LibVersion == "" {
+		r.LibVersion = Version()
+	}
+	if r.Version == 0 {
+		r.Version = reportVersion
+	}
+	r.Tags = normalizeTags(ri.Options.DefaultTags, r.Tags, ro.tags)
+	ri.setExtra(&r)
+	if cut {
+		keepFullDescription(&r, original)
+	}
+	r.setSuppressed(entry)
+	result := ri.output(context.Background(), &r, throttleKey{hash: hash, level: r.Level})
+	if result.Err != nil {
+		ri.LogDebug("AddReport: %v", result.Err)
+		return false
 	}
 	return true
 }
-func (ri *ReportIssues) liveWorker() {
-	ri.LogDebug("Starting live worker")
-	for {
-		ri.Mutex.Lock()
-		if len(ri.Buffer) > 0 {
-			ri.LogDebug("Processing report from buffer")
-			payload := ri.Buffer[0]
-			ri.Buffer = ri.Buffer[1:]
-			ri.Mutex.Unlock()
-			ri.LogDebug("Sending HTTP POST request for IssueID %d", payload.IssueID)
-			submission := ReportSubmission{
-				Issue: payload,
-			}
-			resp, err := ri.restyClient.R().
-				SetHeader("Content-Type", "application/json").
-				SetBody(submission).
-				Post(ri.Options.Server)
-			if err != nil {
-				fmt.Printf("Error sending HTTP request: %v\n", err)
-			} else {
-				ri.LogDebug("HTTP request sent, response status: %s", resp.Status())
+
+// prepare runs Options.BeforeSend on the report and truncates the report it
+// returns, see truncate. It returns nil when BeforeSend drops the report,
+// forgetting its throttle key.
+func (ri *ReportIssues) prepare(report *Report, key throttleKey) *Report {
+	if report = ri.beforeSend(report); ri.dropped(report, key) {
+		return nil
+	}
+	ri.truncate(report)
+	return report
+}
+
+// output buffers the report in live mode, or writes it to a file otherwise,
+// once prepared. key is the throttle key of the report, forgotten when
+// BeforeSend drops it.
+func (ri *ReportIssues) output(ctx context.Context, report *Report, key throttleKey) Result {
+	if report = ri.prepare(report, key); report == nil {
+		return Result{Reason: ResultDropped}
+	}
+	if ri.Options.Live {
+		if err := ri.enqueue(ctx, *report); err != nil {
+			return Result{Reason: ResultError, Report: report, Err: err}
+		}
+		ri.logIssue("buffered", report.id(), report.Level, "Report added to live buffer: IssueID %d - total buffer size: %d", report.IssueID, ri.BufferLen())
+		return Result{Reason: ResultBuffered, Report: report}
+	}
+	if err := ctx.Err(); err != nil {
+		return Result{Reason: ResultError, Report: report, Err: err}
+	}
+	if err := ri.writeFile(report); err != nil {
+		return Result{Reason: ResultError, Report: report, Err: err}
+	}
+	return Result{Reason: ResultWrittenToFile, Report: report}
+}
+
+// ErrFolderMissing is returned when a report file could not be written
+// because Folder or FolderTmp does not exist, see Options.AutoCreateFolder.
+var ErrFolderMissing = errors.New("report folder does not exist")
+
+// writeFile writes the report as JSON into the folder. The data is written
+// to a temporary file first and renamed into place, so readers never observe
+// a partially written report. With FileOutputNDJSON the report is appended
+// to a single file instead, see writeNDJSON.
+func (ri *ReportIssues) writeFile(report *Report) error {
+	if ri.Options.FileOutput == FileOutputNDJSON {
+		return ri.writeNDJSON(report)
+	}
+	if ri.templateErr != nil {
+		return ri.templateErr
+	}
+	fullFilename := filepath.Join(ri.Options.Folder, ri.reportFilename(report))
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("marshalling report %d: %w", report.IssueID, err)
+	}
+	if ri.Options.EncryptionKey != nil {
+		if data, err = encrypt(data, ri.Options.EncryptionKey); err != nil {
+			return fmt.Errorf("encrypting report %d: %w", report.IssueID, err)
+		}
+		fullFilename += EncryptedExt
+	}
+	tmpFolder := ri.Options.FolderTmp
+	if tmpFolder == "" {
+		tmpFolder = ri.Options.Folder
+	}
+	if ri.Options.AutoCreateFolder && !ri.folderReady.Load() {
+		if err := errors.Join(os.MkdirAll(ri.Options.Folder, 0755), os.MkdirAll(tmpFolder, 0755)); err != nil {
+			return fmt.Errorf("creating report folder: %w", err)
+		}
+		ri.folderReady.Store(true)
+	}
+	mode := ri.Options.FileMode
+	if mode == 0 {
+		mode = defaultOptions.FileMode
+	}
+	err = writeFileAtomic(tmpFolder, fullFilename, data, mode)
+	if err != nil {
+		ri.stats.failed.Add(1)
+		if errors.Is(err, fs.ErrNotExist) {
+			for _, folder := range []string{ri.Options.Folder, tmpFolder} {
+				if _, statErr := os.Stat(folder); errors.Is(statErr, fs.ErrNotExist) {
+					return fmt.Errorf("writing report %d: %w: %s", report.IssueID, ErrFolderMissing, folder)
+				}
 			}
-		} else {
-			ri.Mutex.Unlock()
 		}
-		ri.LogDebug("Sleeping for 1 second, buffer size: %d", len(ri.Buffer))
-		time.Sleep(1 * time.Second)
+		return fmt.Errorf("writing report %d: %w", report.IssueID, err)
 	}
+	ri.logIssue("written", report.id(), report.Level, "Report written to file: %s", fullFilename)
+	return nil
+}
+
+// writeTemp writes data to the temporary file of writeFileAtomic, replaced
+// by tests to fail partway.
+var writeTemp = func(w io.Writer, data []byte) error {
+	_, err := w.Write(data)
+	return err
+}
+
+// writeFileAtomic writes data to a temporary file in tmpFolder and renames it
+// to name. tmpFolder must be on the same filesystem as name.
+func writeFileAtomic(tmpFolder, name string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(tmpFolder, filepath.Base(name)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+	if err := writeTemp(tmp, data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
 }
 
 // Convenience methods for different logging levels:
 
 // Fatal reports an issue with "fatal" level.
 func (ri *ReportIssues) Fatal(issue string, extra map[string]interface{}, options map[string]interface{}) bool {
-	return ri.Add(issue, extra, "fatal", options)
+	return ri.Add(issue, extra, LevelFatal, options)
 }
 
 // Warning reports an issue with "warning" level.
 func (ri *ReportIssues) Warning(issue string, extra map[string]interface{}, options map[string]interface{}) bool {
-	return ri.Add(issue, extra, "warning", options)
+	return ri.Add(issue, extra, LevelWarning, options)
 }
 
 // Debug reports an issue with "debug" level.
 func (ri *ReportIssues) Debug(issue string, extra map[string]interface{}, options map[string]interface{}) bool {
-	return ri.Add(issue, extra, "debug", options)
+	return ri.Add(issue, extra, LevelDebug, options)
 }
 
 // Info reports an issue with "info" level.
 func (ri *ReportIssues) Info(issue string, extra map[string]interface{}, options map[string]interface{}) bool {
-	return ri.Add(issue, extra, "info", options)
+	return ri.Add(issue, extra, LevelInfo, options)
 }
 
 // Error reports an issue with "error" level.
 func (ri *ReportIssues) Error(issue string, extra map[string]interface{}, options map[string]interface{}) bool {
-	return ri.Add(issue, extra, "error", options)
+	return ri.Add(issue, extra, LevelError, options)
+}
+
+// FatalE reports an issue with "fatal" level, see AddE.
+func (ri *ReportIssues) FatalE(issue string, extra map[string]interface{}, options map[string]interface{}) error {
+	return ri.AddE(issue, extra, LevelFatal, options)
+}
+
+// WarningE reports an issue with "warning" level, see AddE.
+func (ri *ReportIssues) WarningE(issue string, extra map[string]interface{}, options map[string]interface{}) error {
+	return ri.AddE(issue, extra, LevelWarning, options)
+}
+
+// DebugE reports an issue with "debug" level, see AddE.
+func (ri *ReportIssues) DebugE(issue string, extra map[string]interface{}, options map[string]interface{}) error {
+	return ri.AddE(issue, extra, LevelDebug, options)
+}
+
+// InfoE reports an issue with "info" level, see AddE.
+func (ri *ReportIssues) InfoE(issue string, extra map[string]interface{}, options map[string]interface{}) error {
+	return ri.AddE(issue, extra, LevelInfo, options)
+}
+
+// ErrorE reports an issue with "error" level, see AddE.
+func (ri *ReportIssues) ErrorE(issue string, extra map[string]interface{}, options map[string]interface{}) error {
+	return ri.AddE(issue, extra, LevelError, options)
+}
+
+// FatalContext reports an issue with "fatal" level, see AddContext.
+func (ri *ReportIssues) FatalContext(ctx context.Context, issue string, extra map[string]interface{}, options map[string]interface{}) error {
+	return ri.AddContext(ctx, issue, extra, LevelFatal, options)
+}
+
+// WarningContext reports an issue with "warning" level, see AddContext.
+func (ri *ReportIssues) WarningContext(ctx context.Context, issue string, extra map[string]interface{}, options map[string]interface{}) error {
+	return ri.AddContext(ctx, issue, extra, LevelWarning, options)
+}
+
+// DebugContext reports an issue with "debug" level, see AddContext.
+func (ri *ReportIssues) DebugContext(ctx context.Context, issue string, extra map[string]interface{}, options map[string]interface{}) error {
+	return ri.AddContext(ctx, issue, extra, LevelDebug, options)
+}
+
+// InfoContext reports an issue with "info" level, see AddContext.
+func (ri *ReportIssues) InfoContext(ctx context.Context, issue string, extra map[string]interface{}, options map[string]interface{}) error {
+	return ri.AddContext(ctx, issue, extra, LevelInfo, options)
+}
+
+// ErrorContext reports an issue with "error" level, see AddContext.
+func (ri *ReportIssues) ErrorContext(ctx context.Context, issue string, extra map[string]interface{}, options map[string]interface{}) error {
+	return ri.AddContext(ctx, issue, extra, LevelError, options)
+}
+
+// Fatalf reports a printf-style formatted issue with "fatal" level.
+func (ri *ReportIssues) Fatalf(format string, args ...interface{}) bool {
+	return ri.addf(context.Background(), nil, LevelFatal, format, args...)
+}
+
+// Warningf reports a printf-style formatted issue with "warning" level.
+func (ri *ReportIssues) Warningf(format string, args ...interface{}) bool {
+	return ri.addf(context.Background(), nil, LevelWarning, format, args...)
+}
+
+// Debugf reports a printf-style formatted issue with "debug" level.
+func (ri *ReportIssues) Debugf(format string, args ...interface{}) bool {
+	return ri.addf(context.Background(), nil, LevelDebug, format, args...)
+}
+
+// Infof reports a printf-style formatted issue with "info" level.
+func (ri *ReportIssues) Infof(format string, args ...interface{}) bool {
+	return ri.addf(context.Background(), nil, LevelInfo, format, args...)
+}
+
+// Errorf reports a printf-style formatted issue with "error" level.
+func (ri *ReportIssues) Errorf(format string, args ...interface{}) bool {
+	return ri.addf(context.Background(), nil, LevelError, format, args...)
+}
+
+// FatalfWith reports a printf-style formatted issue with extra data and "fatal" level.
+func (ri *ReportIssues) FatalfWith(extra map[string]interface{}, format string, args ...interface{}) bool {
+	return ri.addf(context.Background(), extra, LevelFatal, format, args...)
+}
+
+// WarningfWith reports a printf-style formatted issue with extra data and "warning" level.
+func (ri *ReportIssues) WarningfWith(extra map[string]interface{}, format string, args ...interface{}) bool {
+	return ri.addf(context.Background(), extra, LevelWarning, format, args...)
+}
+
+// DebugfWith reports a printf-style formatted issue with extra data and "debug" level.
+func (ri *ReportIssues) DebugfWith(extra map[string]interface{}, format string, args ...interface{}) bool {
+	return ri.addf(context.Background(), extra, LevelDebug, format, args...)
+}
+
+// InfofWith reports a printf-style formatted issue with extra data and "info" level.
+func (ri *ReportIssues) InfofWith(extra map[string]interface{}, format string, args ...interface{}) bool {
+	return ri.addf(context.Background(), extra, LevelInfo, format, args...)
+}
+
+// ErrorfWith reports a printf-style formatted issue with extra data and "error" level.
+func (ri *ReportIssues) ErrorfWith(extra map[string]interface{}, format string, args ...interface{}) bool {
+	return ri.addf(context.Background(), extra, LevelError, format, args...)
 }
 
-// LogDebug prints debug messages if Debug mode is enabled.
+// addf reports a formatted issue, grouped by format when GroupByFormat is set.
+func (ri *ReportIssues) addf(ctx context.Context, extra map[string]interface{}, level Level, format string, args ...interface{}) bool {
+	groupKey := ""
+	if ri.Options.GroupByFormat {
+		groupKey = format
+	}
+	result := ri.addResult(ctx, fmt.Sprintf(format, args...), groupKey, extra, level, nil)
+	if result.Err != nil {
+		ri.LogDebug("Add: %v", result.Err)
+	}
+	return result.err() == nil
+}
+
+// LogDebug prints debug messages if Debug mode is enabled, to Options.Logger
+// when set. Messages always go to Options.SlogLogger when set.
 func (ri *ReportIssues) LogDebug(format string, args ...interface{}) {
-	if ri.Options.Debug {
-		color.New(color.FgBlue).Printf("[DEBUG] "+format+"\n", args...)
+	if ri.Options.SlogLogger != nil {
+		ri.slogDebug([]slog.Attr{slog.String("app", ri.AppName)}, format, args...)
+		return
+	}
+	if !ri.Options.Debug {
+		return
+	}
+	if ri.Options.Logger != nil {
+		ri.Options.Logger.Debugf(format, args...)
+		return
+	}
+	color.New(color.FgBlue).Printf("[DEBUG] "+format+"\n", args...)
+}
+
+// logIssue logs an event about a single issue, with the action, IssueID and
+// level as attributes when Options.SlogLogger is set.
+func (ri *ReportIssues) logIssue(action string, issueID uint64, level, format string, args ...interface{}) {
+	if ri.Options.SlogLogger == nil {
+		ri.LogDebug(format, args...)
+		return
+	}
+	ri.slogDebug([]slog.Attr{
+		slog.String("app", ri.AppName),
+		slog.Any("issue_id", issueID),
+		slog.String("level", level),
+		slog.String("action", action),
+	}, format, args...)
+}
+
+// slogDebug emits a debug record to Options.SlogLogger, unless its handler
+// ignores the debug level.
+func (ri *ReportIssues) slogDebug(attrs []slog.Attr, format string, args ...interface{}) {
+	ctx := context.Background()
+	if ri.Options.SlogLogger.Enabled(ctx, slog.LevelDebug) {
+		ri.Options.SlogLogger.LogAttrs(ctx, slog.LevelDebug, fmt.Sprintf(format, args...), attrs...)
 	}
 }
@@ -0,0 +1,139 @@
+package issues
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	custom := &recordingLogger{}
+	ri := NewReportIssues("myapp",
+		WithFolder(t.TempDir()),
+		WithSlogLogger(logger),
+		WithLogger(custom),
+		WithDebug(true),
+	)
+	ri.Add("disk full", nil, LevelError, nil)
+	ri.Add("disk full", nil, LevelError, nil)
+
+	var written, throttled string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		switch {
+		case strings.Contains(line, "action=written"):
+			written = line
+		case strings.Contains(line, "action=throttled"):
+			throttled = line
+		}
+	}
+	for name, line := range map[string]string{"written": written, "throttled": throttled} {
+		if line == "" {
+			t.Errorf("no %s record in:\n%s", name, buf.String())
+			continue
+		}
+		for _, attr := range []string{"level=DEBUG", "app=myapp", "issue_id=", "level=error"} {
+			if !strings.Contains(line, attr) {
+				t.Errorf("%s record %q lacks %s", name, line, attr)
+			}
+		}
+	}
+	if len(custom.messages) != 0 {
+		t.Errorf("Logger got %d messages, want none with a SlogLogger set", len(custom.messages))
+	}
+}
+
+func TestSlogLoggerIgnoresDebug(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	ri := NewReportIssues("myapp", WithFolder(t.TempDir()), WithSlogLogger(logger))
+	ri.Add("disk full", nil, LevelError, nil)
+	if buf.Len() != 0 {
+		t.Errorf("info handler got debug records:\n%s", buf.String())
+	}
+}
+
+func TestFileMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("no Unix permission bits on Windows")
+	}
+	for _, tt := range []struct {
+		name   string
+		mode   os.FileMode
+		ndjson bool
+		want   os.FileMode
+	}{
+		{"default", 0, false, 0644},
+		{"private", 0600, false, 0600},
+		{"group", 0640, false, 0640},
+		{"ndjson default", 0, true, 0644},
+		{"ndjson private", 0600, true, 0600},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			options := []func(*Options){WithFolder(t.TempDir()), WithFileMode(tt.mode)}
+			if tt.ndjson {
+				options = append(options, WithNDJSONOutput(100))
+			}
+			ri := NewReportIssues("test", options...)
+			if !ri.Add("disk full", nil, LevelError, nil) {
+				t.Fatal("Add failed")
+			}
+			entries, err := os.ReadDir(ri.Options.Folder)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) == 0 {
+				t.Fatal("no report file written")
+			}
+			// The NDJSON lock file gets the mode as well.
+			for _, entry := range entries {
+				info, err := entry.Info()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := info.Mode().Perm(); got != tt.want {
+					t.Errorf("%s has mode %v, want %v", entry.Name(), got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteFileAtomicPartialWrite(t *testing.T) {
+	defer func(write func(io.Writer, []byte) error) { writeTemp = write }(writeTemp)
+	errDiskFull := errors.New("no space left on device")
+	writeTemp = func(w io.Writer, data []byte) error {
+		if _, err := w.Write(data[:len(data)/2]); err != nil {
+			return err
+		}
+		return errDiskFull
+	}
+
+	ri := NewReportIssues("test", WithFolder(t.TempDir()))
+	if err := ri.AddE("half written", nil, LevelError, nil); !errors.Is(err, errDiskFull) {
+		t.Errorf("AddE() = %v, want %v", err, errDiskFull)
+	}
+	entries, err := os.ReadDir(ri.Options.Folder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		t.Errorf("%s left behind by the failed write", entry.Name())
+	}
+}
+
+func TestWriteFileFolderMissing(t *testing.T) {
+	folder := filepath.Join(t.TempDir(), "missing")
+	ri := NewReportIssues("test", WithFolder(folder), WithAutoCreateFolder(false))
+	err := ri.AddE("no folder", nil, LevelError, nil)
+	if !errors.Is(err, ErrFolderMissing) || !strings.Contains(err.Error(), folder) {
+		t.Errorf("AddE() = %v, want %v naming %s", err, ErrFolderMissing, folder)
+	}
+}
@@ -0,0 +1,95 @@
+package issues
+
+import (
+	"fmt"
+	"time"
+)
+
+// Option keys interpreted by the library in the options map passed to Add.
+// They are removed from the options sent with the report, every other key is
+// passed through to the server verbatim.
+const (
+	// OptionInterval overrides the throttle interval for this call, as a
+	// time.Duration, a string accepted by time.ParseDuration, or a number
+	// of seconds, such as the float64 decoded from JSON. Zero never
+	// throttles the call.
+	OptionInterval = "interval"
+	// OptionFingerprint is a string replacing the issue text in the IssueID
+	// and throttle hash, so differently worded occurrences of the same
+	// problem are grouped. The description is still sent unchanged.
+	OptionFingerprint = "fingerprint"
+	// OptionTags adds tags to the report, next to Options.DefaultTags, as a
+	// []string or a comma-separated string.
+	OptionTags = "tags"
+)
+
+// reportOptions holds the option keys interpreted by the library.
+type reportOptions struct {
+	interval    time.Duration
+	hasInterval bool
+	fingerprint string
+	tags        []string
+}
+
+// parseReportOptions extracts the library option keys from options and
+// returns them along with the options left to send, copied only when a key
+// had to be removed.
+func parseReportOptions(options map[string]interface{}) (reportOptions, map[string]interface{}, error) {
+	var ro reportOptions
+	found := 0
+	if value, ok := options[OptionInterval]; ok {
+		switch v := value.(type) {
+		case time.Duration:
+			ro.interval = v
+		case string:
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return ro, options, fmt.Errorf("option %q: %w", OptionInterval, err)
+			}
+			ro.interval = d
+		case float64:
+			ro.interval = time.Duration(v * float64(time.Second))
+		case float32:
+			ro.interval = time.Duration(float64(v) * float64(time.Second))
+		case int:
+			ro.interval = time.Duration(v) * time.Second
+		case int32:
+			ro.interval = time.Duration(v) * time.Second
+		case int64:
+			ro.interval = time.Duration(v) * time.Second
+		default:
+			return ro, options, fmt.Errorf("option %q: unsupported type %T", OptionInterval, value)
+		}
+		ro.hasInterval = true
+		found++
+	}
+	if value, ok := options[OptionFingerprint]; ok {
+		fingerprint, ok := value.(string)
+		if !ok {
+			return ro, options, fmt.Errorf("option %q: unsupported type %T", OptionFingerprint, value)
+		}
+		ro.fingerprint = fingerprint
+		found++
+	}
+	if value, ok := options[OptionTags]; ok {
+		tags, err := parseTags(value)
+		if err != nil {
+			return ro, options, err
+		}
+		ro.tags = tags
+		found++
+	}
+	if found == 0 {
+		return ro, options, nil
+	}
+
+	rest := make(map[string]interface{}, len(options)-found)
+	for k, v := range options {
+		switch k {
+		case OptionInterval, OptionFingerprint, OptionTags:
+		default:
+			rest[k] = v
+		}
+	}
+	return ro, rest, nil
+}
@@ -0,0 +1,77 @@
+package issues
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrThrottled is returned by AddE when the issue was reported too recently.
+var ErrThrottled = errors.New("issue throttled")
+
+// ErrFiltered is returned by AddE when the level is below Options.MinLevel.
+var ErrFiltered = errors.New("issue level below minimum level")
+
+// ResultReason tells what happened to an issue passed to AddResult.
+type ResultReason int
+
+const (
+	// ResultThrottled means the issue was reported too recently and dropped.
+	ResultThrottled ResultReason = iota
+	// ResultBuffered means the report was queued for the live worker.
+	ResultBuffered
+	// ResultWrittenToFile means the report was written to the folder.
+	ResultWrittenToFile
+	// ResultError means the report was generated but could not be persisted.
+	ResultError
+	// ResultFiltered means the level was below Options.MinLevel.
+	ResultFiltered
+	// ResultDisabled means the ReportIssues is disabled, see Disable.
+	ResultDisabled
+	// ResultDropped means Options.BeforeSend dropped the report.
+	ResultDropped
+)
+
+// String returns a human readable name of the reason.
+func (r ResultReason) String() string {
+	switch r {
+	case ResultThrottled:
+		return "throttled"
+	case ResultBuffered:
+		return "buffered"
+	case ResultWrittenToFile:
+		return "written"
+	case ResultError:
+		return "failed"
+	case ResultFiltered:
+		return "filtered"
+	case ResultDisabled:
+		return "disabled"
+	case ResultDropped:
+		return "dropped"
+	}
+	return "unknown"
+}
+
+// Result describes the outcome of AddResult.
+type Result struct {
+	Reason ResultReason
+	// Report is the generated report, nil when throttled or dropped.
+	Report *Report
+	// NextAllowed is when a throttled issue may be reported again.
+	NextAllowed time.Time
+	// Err is the underlying failure when Reason is ResultError.
+	Err error
+}
+
+// err returns the error AddE reports for this result, nil on success.
+func (r Result) err() error {
+	switch r.Reason {
+	case ResultThrottled:
+		return ErrThrottled
+	case ResultFiltered:
+		return ErrFiltered
+	case ResultDropped:
+		return ErrDropped
+	}
+	return r.Err
+}
@@ -0,0 +1,66 @@
+package issues
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseRetryAfter parses a Retry-After header value, either a number of
+// seconds or an HTTP date, into the delay from now. It returns false when
+// the value is missing or invalid.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	return max(date.Sub(now), 0), true
+}
+
+// retryAfter returns the delay the server asked for with a Retry-After
+// header on a 429 or 503 response, capped at Options.MaxRetryAfter.
+func (ri *ReportIssues) retryAfter(err error) time.Duration {
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.RetryAfter <= 0 {
+		return 0
+	}
+	if statusErr.StatusCode != http.StatusTooManyRequests && statusErr.StatusCode != http.StatusServiceUnavailable {
+		return 0
+	}
+	limit := ri.Options.MaxRetryAfter
+	if limit <= 0 {
+		limit = defaultOptions.MaxRetryAfter
+	}
+	return min(statusErr.RetryAfter, limit)
+}
+
+// pauseSending stops the live worker from sending anything for delay.
+func (ri *ReportIssues) pauseSending(delay time.Duration) {
+	until := ri.now().Add(delay)
+	ri.Mutex.Lock()
+	if until.After(ri.pausedUntil) {
+		ri.pausedUntil = until
+	}
+	ri.Mutex.Unlock()
+	ri.LogDebug("Server asked to retry after %s, pausing sends", delay)
+}
+
+// pausedFor returns how long sending remains paused at now, see
+// pauseSending.
+func (ri *ReportIssues) pausedFor(now time.Time) time.Duration {
+	ri.Mutex.Lock()
+	defer ri.Mutex.Unlock()
+	return max(ri.pausedUntil.Sub(now), 0)
+}
@@ -0,0 +1,83 @@
+package issues
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"2", 2 * time.Second, true},
+		{" 120 ", 2 * time.Minute, true},
+		{"0", 0, true},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"", 0, false},
+		{"-5", 0, false},
+		{"soon", 0, false},
+	} {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseRetryAfter(%q) = %s, %v, want %s, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestRetryAfterCapped(t *testing.T) {
+	ri := NewReportIssues("test", WithMaxRetryAfter(time.Minute))
+	for _, tt := range []struct {
+		err  *StatusError
+		want time.Duration
+	}{
+		{&StatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: 2 * time.Second}, 2 * time.Second},
+		{&StatusError{StatusCode: http.StatusServiceUnavailable, RetryAfter: time.Hour}, time.Minute},
+		{&StatusError{StatusCode: http.StatusInternalServerError, RetryAfter: 2 * time.Second}, 0},
+		{&StatusError{StatusCode: http.StatusTooManyRequests}, 0},
+	} {
+		if got := ri.retryAfter(tt.err); got != tt.want {
+			t.Errorf("retryAfter(%d, %s) = %s, want %s", tt.err.StatusCode, tt.err.RetryAfter, got, tt.want)
+		}
+	}
+}
+
+func TestRetryAfterPausesWorker(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		arrivals []time.Time
+	)
+	ri := newLiveReporter(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		arrivals = append(arrivals, time.Now())
+		if len(arrivals) == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	})
+	ri.Add("rate limited", nil, LevelError, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := ri.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(arrivals) != 2 {
+		t.Fatalf("server got %d requests, want 2", len(arrivals))
+	}
+	if gap := arrivals[1].Sub(arrivals[0]); gap < 2*time.Second {
+		t.Errorf("retried after %s, want the 2s of Retry-After rather than the 10ms backoff", gap)
+	}
+	if stats := ri.GetStats(); stats.TotalSubmitted != 1 {
+		t.Errorf("TotalSubmitted = %d, want 1", stats.TotalSubmitted)
+	}
+}
@@ -0,0 +1,126 @@
+package issues
+
+import (
+	"context"
+	"maps"
+)
+
+// Scope reports through a ReportIssues with additional meta, see
+// ReportIssues.With. It shares the buffer, throttling, live worker and
+// options of its ReportIssues, so scopes are cheap to create.
+type Scope struct {
+	ri   *ReportIssues
+	meta map[string]string
+}
+
+// scopeMetaKey is the context key carrying the meta of a Scope.
+type scopeMetaKey struct{}
+
+// With returns a Scope adding meta to every report it generates, on top of
+// the ReportIssues' Meta. meta is copied.
+func (ri *ReportIssues) With(meta map[string]string) *Scope {
+	return &Scope{ri: ri, meta: maps.Clone(meta)}
+}
+
+// With returns a nested Scope whose meta is merged with s's, the keys of
+// meta winning on conflicts.
+func (s *Scope) With(meta map[string]string) *Scope {
+	merged := maps.Clone(s.meta)
+	if merged == nil {
+		merged = make(map[string]string, len(meta))
+	}
+	for key, value := range meta {
+		merged[key] = value
+	}
+	return &Scope{ri: s.ri, meta: merged}
+}
+
+// Reporter returns the ReportIssues s reports through.
+func (s *Scope) Reporter() *ReportIssues {
+	return s.ri
+}
+
+// context returns ctx carrying the meta of s, see contextMeta.
+func (s *Scope) context(ctx context.Context) context.Context {
+	return context.WithValue(ctx, scopeMetaKey{}, s.meta)
+}
+
+// Add behaves like ReportIssues.Add with the meta of s.
+func (s *Scope) Add(issue string, extra map[string]interface{}, level Level, options map[string]interface{}) bool {
+	return s.ri.AddWithContext(s.context(context.Background()), issue, extra, level, options)
+}
+
+// AddWithContext behaves like ReportIssues.AddWithContext with the meta of s.
+func (s *Scope) AddWithContext(ctx context.Context, issue string, extra map[string]interface{}, level Level, options map[string]interface{}) bool {
+	return s.ri.AddWithContext(s.context(ctx), issue, extra, level, options)
+}
+
+// AddE behaves like ReportIssues.AddE with the meta of s.
+func (s *Scope) AddE(issue string, extra map[string]interface{}, level Level, options map[string]interface{}) error {
+	return s.ri.AddContext(s.context(context.Background()), issue, extra, level, options)
+}
+
+// AddContext behaves like ReportIssues.AddContext with the meta of s.
+func (s *Scope) AddContext(ctx context.Context, issue string, extra map[string]interface{}, level Level, options map[string]interface{}) error {
+	return s.ri.AddContext(s.context(ctx), issue, extra, level, options)
+}
+
+// AddResult behaves like ReportIssues.AddResult with the meta of s.
+func (s *Scope) AddResult(issue string, extra map[string]interface{}, level Level, options map[string]interface{}) Result {
+	return s.ri.addResult(s.context(context.Background()), issue, "", extra, level, options)
+}
+
+// ReportError behaves like ReportIssues.ReportError with the meta of s.
+func (s *Scope) ReportError(err error, level Level, extra map[string]interface{}) bool {
+	return s.ri.reportError(s.context(context.Background()), err, level, extra)
+}
+
+// Fatal reports an issue with "fatal" level.
+func (s *Scope) Fatal(issue string, extra map[string]interface{}, options map[string]interface{}) bool {
+	return s.Add(issue, extra, LevelFatal, options)
+}
+
+// Warning reports an issue with "warning" level.
+func (s *Scope) Warning(issue string, extra map[string]interface{}, options map[string]interface{}) bool {
+	return s.Add(issue, extra, LevelWarning, options)
+}
+
+// Debug reports an issue with "debug" level.
+func (s *Scope) Debug(issue string, extra map[string]interface{}, options map[string]interface{}) bool {
+	return s.Add(issue, extra, LevelDebug, options)
+}
+
+// Info reports an issue with "info" level.
+func (s *Scope) Info(issue string, extra map[string]interface{}, options map[string]interface{}) bool {
+	return s.Add(issue, extra, LevelInfo, options)
+}
+
+// Error reports an issue with "error" level.
+func (s *Scope) Error(issue string, extra map[string]interface{}, options map[string]interface{}) bool {
+	return s.Add(issue, extra, LevelError, options)
+}
+
+// Fatalf reports a printf-style formatted issue with "fatal" level.
+func (s *Scope) Fatalf(format string, args ...interface{}) bool {
+	return s.ri.addf(s.context(context.Background()), nil, LevelFatal, format, args...)
+}
+
+// Warningf reports a printf-style formatted issue with "warning" level.
+func (s *Scope) Warningf(format string, args ...interface{}) bool {
+	return s.ri.addf(s.context(context.Background()), nil, LevelWarning, format, args...)
+}
+
+// Debugf reports a printf-style formatted issue with "debug" level.
+func (s *Scope) Debugf(format string, args ...interface{}) bool {
+	return s.ri.addf(s.context(context.Background()), nil, LevelDebug, format, args...)
+}
+
+// Infof reports a printf-style formatted issue with "info" level.
+func (s *Scope) Infof(format string, args ...interface{}) bool {
+	return s.ri.addf(s.context(context.Background()), nil, LevelInfo, format, args...)
+}
+
+// Errorf reports a printf-style formatted issue with "error" level.
+func (s *Scope) Errorf(format string, args ...interface{}) bool {
+	return s.ri.addf(s.context(context.Background()), nil, LevelError, format, args...)
+}
@@ -0,0 +1,59 @@
+package issues
+
+import (
+	"context"
+	"errors"
+)
+
+// Strategies for Options.FailoverStrategy.
+const (
+	// FailoverPrimaryFirst starts every submission with the first server and
+	// moves down the list on failure.
+	FailoverPrimaryFirst = "primary_first"
+	// FailoverRoundRobin starts every submission with the server that
+	// succeeded last and moves to the next one, wrapping around, on failure.
+	FailoverRoundRobin = "round_robin"
+)
+
+// servers returns the live servers in failover order.
+func (ri *ReportIssues) servers() []string {
+	if len(ri.Options.Servers) > 0 {
+		return ri.Options.Servers
+	}
+	return []string{ri.Options.Server}
+}
+
+// GetCurrentServer returns the server that accepted the last live
+// submission, the first server before any.
+func (ri *ReportIssues) GetCurrentServer() string {
+	servers := ri.servers()
+	return servers[int(ri.serverIndex.Load())%len(servers)]
+}
+
+// postFailover posts body to the servers according to FailoverStrategy until
+// one accepts it. It returns the error of the last server tried, only
+// network errors and 5xx responses moving on to the next server: a 4xx
+// StatusError is returned right away, the other servers being expected to
+// reject the body as well.
+func (ri *ReportIssues) postFailover(ctx context.Context, body []byte) (int, error) {
+	servers := ri.servers()
+	start := 0
+	if ri.Options.FailoverStrategy == FailoverRoundRobin {
+		start = int(ri.serverIndex.Load()) % len(servers)
+	}
+	var status int
+	var err error
+	for i := range servers {
+		index := (start + i) % len(servers)
+		if status, err = ri.post(ctx, servers[index], body); err == nil {
+			ri.serverIndex.Store(int32(index))
+			return status, nil
+		}
+		var statusErr *StatusError
+		if ctx.Err() != nil || errors.As(err, &statusErr) && statusErr.StatusCode < 500 {
+			return status, err
+		}
+		ri.LogDebug("Server %s failed: %v", servers[index], err)
+	}
+	return status, err
+}
@@ -0,0 +1,60 @@
+package issues
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// countingServer answers every request with status and counts them.
+func countingServer(t *testing.T, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+func TestPostFailover(t *testing.T) {
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	tests := []struct {
+		name        string
+		primary     int
+		wantBackup  int32
+		wantErrCode int
+	}{
+		{"client error", http.StatusBadRequest, 0, http.StatusBadRequest},
+		{"unauthorized", http.StatusUnauthorized, 0, http.StatusUnauthorized},
+		{"server error", http.StatusServiceUnavailable, 1, 0},
+		{"network error", 0, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := down.URL
+			if tt.primary != 0 {
+				srv, _ := countingServer(t, tt.primary)
+				primary = srv.URL
+			}
+			backup, hits := countingServer(t, http.StatusOK)
+			ri := NewReportIssues("test", WithServers(FailoverPrimaryFirst, primary, backup.URL))
+			_, err := ri.postFailover(context.Background(), []byte("{}"))
+			if got := hits.Load(); got != tt.wantBackup {
+				t.Errorf("backup hit %d times, want %d", got, tt.wantBackup)
+			}
+			var statusErr *StatusError
+			switch {
+			case tt.wantErrCode == 0 && err != nil:
+				t.Errorf("postFailover() = %v, want the backup to accept", err)
+			case tt.wantErrCode != 0 && (!errors.As(err, &statusErr) || statusErr.StatusCode != tt.wantErrCode):
+				t.Errorf("postFailover() = %v, want a %d StatusError", err, tt.wantErrCode)
+			}
+		})
+	}
+}
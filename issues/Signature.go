@@ -0,0 +1,38 @@
+package issues
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// SignatureHeader carries the HMAC-SHA256 signature of live submissions when
+// Options.HMACSecret is set.
+const SignatureHeader = "X-Coadmin-Signature"
+
+// Sign returns the SignatureHeader value of body: "sha256=" followed by the
+// hex-encoded HMAC-SHA256 of body keyed with secret. The signed input is the
+// exact request body, the encoding/json output of the ReportSubmission or
+// BulkReportSubmission; it is not canonicalized.
+func Sign(body, secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature reports whether sig, a SignatureHeader value, is the
+// signature of the raw request body with secret.
+func VerifySignature(body []byte, sig string, secret []byte) bool {
+	digest, ok := strings.CutPrefix(sig, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
@@ -0,0 +1,88 @@
+package issues
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// libPackagePrefix identifies frames belonging to this package, which are
+// never reported as part of a stack trace.
+const libPackagePrefix = "github.com/7c/coadmin-golib/issues."
+
+// maxLibFrames bounds how many frames of this package may sit on top of the
+// caller's frames (Add, generate, convenience wrappers and so on).
+const maxLibFrames = 16
+
+// callerFrames returns up to max frames of the current goroutine, starting at
+// the first frame outside this package and dropping skip more.
+func callerFrames(max, skip int) []runtime.Frame {
+	if max <= 0 {
+		return nil
+	}
+	if skip < 0 {
+		skip = 0
+	}
+	pcs := make([]uintptr, max+skip+maxLibFrames)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	result := make([]runtime.Frame, 0, max)
+	inLib := true
+	for len(result) < max {
+		frame, more := frames.Next()
+		if inLib && isLibFrame(frame) {
+			if !more {
+				break
+			}
+			continue
+		}
+		inLib = false
+		if skip > 0 {
+			skip--
+		} else {
+			result = append(result, frame)
+		}
+		if !more {
+			break
+		}
+	}
+	return result
+}
+
+// isLibFrame reports whether frame belongs to this package. The package's
+// own tests count as callers.
+func isLibFrame(frame runtime.Frame) bool {
+	return strings.HasPrefix(frame.Function, libPackagePrefix) && !strings.HasSuffix(frame.File, "_test.go")
+}
+
+// captureStackTrace returns up to depth formatted frames of the caller's
+// stack. A depth of zero disables capture and yields an empty trace.
+func captureStackTrace(depth, skip int) []string {
+	frames := callerFrames(depth, skip)
+	trace := make([]string, 0, len(frames))
+	for _, frame := range frames {
+		trace = append(trace, formatFrame(frame))
+	}
+	return trace
+}
+
+// captureCaller returns the first frame outside this package, dropping skip
+// more frames, or "unknown" when it cannot be determined.
+func captureCaller(skip int) string {
+	frames := callerFrames(1, skip)
+	if len(frames) == 0 {
+		return "unknown"
+	}
+	return formatFrame(frames[0])
+}
+
+// formatFrame renders a frame as "package.Func (file.go:line)".
+func formatFrame(frame runtime.Frame) string {
+	function := frame.Function
+	if i := strings.LastIndex(function, "/"); i >= 0 {
+		function = function[i+1:]
+	}
+	return fmt.Sprintf("%s (%s:%d)", function, filepath.Base(frame.File), frame.Line)
+}
@@ -0,0 +1,35 @@
+package issues
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCallerIsTheTest(t *testing.T) {
+	var caller string
+	var trace []string
+	ri := NewReportIssues("test",
+		WithFolder(t.TempDir()),
+		WithBeforeSend(func(r *Report) *Report {
+			caller, trace = r.Caller, r.StackTrace
+			return r
+		}),
+	)
+	_, _, line, _ := runtime.Caller(0)
+	ri.Error("disk full", nil, nil)
+
+	want := fmt.Sprintf("issues.TestCallerIsTheTest (StackTrace_test.go:%d)", line+1)
+	if caller != want {
+		t.Fatalf("Caller = %q, want %q", caller, want)
+	}
+	if len(trace) == 0 || trace[0] != want {
+		t.Fatalf("StackTrace starts with %q, want %q", trace, want)
+	}
+	for _, frame := range trace {
+		if strings.Contains(frame, "ReportIssues.go") {
+			t.Errorf("StackTrace holds a library frame: %s", frame)
+		}
+	}
+}
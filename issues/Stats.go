@@ -0,0 +1,82 @@
+package issues
+
+import "sync/atomic"
+
+// Stats is a snapshot of the counters of a ReportIssues, see GetStats.
+type Stats struct {
+	// BufferLen is the number of reports waiting in the live buffer.
+	BufferLen int `json:"buffer_len"`
+	// TotalAdded counts the reports generated, i.e. not throttled or filtered.
+	TotalAdded int64 `json:"total_added"`
+	// TotalThrottled counts the issues suppressed by throttling.
+	TotalThrottled int64 `json:"total_throttled"`
+	// TotalSubmitted counts the reports accepted by the server.
+	TotalSubmitted int64 `json:"total_submitted"`
+	// TotalDropped counts the reports discarded by a full buffer or after
+	// MaxRetries failed attempts.
+	TotalDropped int64 `json:"total_dropped"`
+	// TotalFailed counts the failed submissions and file writes, once per
+	// report and attempt.
+	TotalFailed int64 `json:"total_failed"`
+	// AddedByLevel and ThrottledByLevel break TotalAdded and TotalThrottled
+	// down by level, every level of Levels is present.
+	AddedByLevel     map[string]int64 `json:"added_by_level"`
+	ThrottledByLevel map[string]int64 `json:"throttled_by_level"`
+}
+
+// levelCounters holds one counter per entry of Levels, in the same order.
+type levelCounters [5]atomic.Int64
+
+// add increments the counter of level, ignoring unknown levels.
+func (c *levelCounters) add(level string) {
+	if i := Level(level).severity(); i > 0 {
+		c[len(Levels)-i].Add(1)
+	}
+}
+
+// snapshot returns the counters keyed by level.
+func (c *levelCounters) snapshot() map[string]int64 {
+	m := make(map[string]int64, len(Levels))
+	for i, level := range Levels {
+		m[string(level)] = c[i].Load()
+	}
+	return m
+}
+
+// counters holds the running totals reported by GetStats.
+type counters struct {
+	added     atomic.Int64
+	throttled atomic.Int64
+	submitted atomic.Int64
+	dropped   atomic.Int64
+	failed    atomic.Int64
+
+	addedByLevel     levelCounters
+	throttledByLevel levelCounters
+}
+
+// countAdded counts a report generated at level.
+func (c *counters) countAdded(level string) {
+	c.added.Add(1)
+	c.addedByLevel.add(level)
+}
+
+// countThrottled counts an issue throttled at level.
+func (c *counters) countThrottled(level string) {
+	c.throttled.Add(1)
+	c.throttledByLevel.add(level)
+}
+
+// GetStats returns a snapshot of the reporter's counters.
+func (ri *ReportIssues) GetStats() Stats {
+	return Stats{
+		BufferLen:        ri.BufferLen(),
+		TotalAdded:       ri.stats.added.Load(),
+		TotalThrottled:   ri.stats.throttled.Load(),
+		TotalSubmitted:   ri.stats.submitted.Load(),
+		TotalDropped:     ri.stats.dropped.Load(),
+		TotalFailed:      ri.stats.failed.Load(),
+		AddedByLevel:     ri.stats.addedByLevel.snapshot(),
+		ThrottledByLevel: ri.stats.throttledByLevel.snapshot(),
+	}
+}
@@ -0,0 +1,58 @@
+package issues
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+)
+
+func TestStatsFileOutput(t *testing.T) {
+	ri := NewReportIssues("test", WithFolder(t.TempDir()))
+	ri.Add("disk full", nil, LevelError, nil)
+	ri.Add("disk full", nil, LevelError, nil)
+	ri.Add("cache miss", nil, LevelWarning, nil)
+
+	stats := ri.GetStats()
+	if stats.TotalAdded != 2 || stats.TotalThrottled != 1 || stats.TotalFailed != 0 {
+		t.Errorf("added %d, throttled %d, failed %d, want 2, 1 and 0", stats.TotalAdded, stats.TotalThrottled, stats.TotalFailed)
+	}
+	if stats.AddedByLevel["error"] != 1 || stats.AddedByLevel["warning"] != 1 || stats.ThrottledByLevel["error"] != 1 {
+		t.Errorf("by level: added %v, throttled %v", stats.AddedByLevel, stats.ThrottledByLevel)
+	}
+	files, err := ri.ListReports()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Errorf("got %d report files, want 2", len(files))
+	}
+}
+
+func TestStatsFailedWrite(t *testing.T) {
+	folder := filepath.Join(t.TempDir(), "missing")
+	ri := NewReportIssues("test", WithFolder(folder), WithAutoCreateFolder(false))
+	if ri.Add("disk full", nil, LevelError, nil) {
+		t.Fatal("Add succeeded without a folder")
+	}
+	if stats := ri.GetStats(); stats.TotalFailed != 1 {
+		t.Errorf("TotalFailed = %d, want 1", stats.TotalFailed)
+	}
+}
+
+func TestStatsLive(t *testing.T) {
+	status := http.StatusOK
+	ri := newLiveReporter(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}, WithMaxRetries(0))
+	if err := ri.SubmitReport(context.Background(), Report{IssueID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	status = http.StatusInternalServerError
+	if err := ri.SubmitReport(context.Background(), Report{IssueID: 2}); err == nil {
+		t.Fatal("SubmitReport succeeded on a 500")
+	}
+	if stats := ri.GetStats(); stats.TotalSubmitted != 1 || stats.TotalFailed != 1 || stats.BufferLen != 0 {
+		t.Errorf("submitted %d, failed %d, buffered %d, want 1, 1 and 0", stats.TotalSubmitted, stats.TotalFailed, stats.BufferLen)
+	}
+}
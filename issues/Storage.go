@@ -0,0 +1,118 @@
+package issues
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReportFileInfo describes a report file stored in Folder, see ListReports.
+type ReportFileInfo struct {
+	Path    string
+	IssueID uint32
+	ModTime time.Time
+	Size    int64
+}
+
+// ListReports returns the *.coadmin_issue files of Folder, and the
+// encrypted ones, sorted by ModTime, newest first. The IssueID is taken
+// from the file name with the default FilenameTemplate, from the report
+// itself otherwise; files that cannot be read are skipped,
+// their errors are returned joined together along with the other files.
+func (ri *ReportIssues) ListReports() ([]ReportFileInfo, error) {
+	var files []string
+	for _, pattern := range []string{"*" + ReportExt, "*" + ReportExt + EncryptedExt} {
+		matches, err := filepath.Glob(filepath.Join(ri.Options.Folder, pattern))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	var infos []ReportFileInfo
+	var errs []error
+	for _, file := range files {
+		stat, err := os.Stat(file)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		id, err := ri.fileIssueID(file)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		infos = append(infos, ReportFileInfo{
+			Path:    file,
+			IssueID: id,
+			ModTime: stat.ModTime(),
+			Size:    stat.Size(),
+		})
+	}
+	sort.SliceStable(infos, func(i, j int) bool {
+		return infos[i].ModTime.After(infos[j].ModTime)
+	})
+	return infos, errors.Join(errs...)
+}
+
+// fileIssueID returns the IssueID of the report stored in file, taken from
+// its name with the default FilenameTemplate, from its content otherwise.
+func (ri *ReportIssues) fileIssueID(file string) (uint32, error) {
+	tmpl := ri.Options.FilenameTemplate
+	if tmpl == "" || tmpl == defaultFilenameTemplate {
+		name := strings.TrimSuffix(filepath.Base(file), EncryptedExt)
+		if id, err := strconv.ParseUint(strings.TrimSuffix(name, ReportExt), 10, 64); err == nil {
+			return uint32(id), nil
+		}
+	}
+	report, err := ParseReportFile(file, ri.Options.EncryptionKey)
+	if err != nil {
+		return 0, err
+	}
+	return report.IssueID, nil
+}
+
+// DeleteReport removes the stored files of the issue, wrapping
+// fs.ErrNotExist when there is none.
+func (ri *ReportIssues) DeleteReport(issueID uint32) error {
+	infos, err := ri.ListReports()
+	deleted := 0
+	var errs []error
+	for _, info := range infos {
+		if info.IssueID != issueID {
+			continue
+		}
+		if err := os.Remove(info.Path); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		deleted++
+	}
+	if deleted == 0 && len(errs) == 0 {
+		return errors.Join(fmt.Errorf("report %d: %w", issueID, fs.ErrNotExist), err)
+	}
+	return errors.Join(errs...)
+}
+
+// DeleteAllReports removes every file listed by ListReports and returns how
+// many were removed.
+func (ri *ReportIssues) DeleteAllReports() (int, error) {
+	infos, err := ri.ListReports()
+	errs := []error{err}
+	deleted := 0
+	for _, info := range infos {
+		if err := os.Remove(info.Path); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		deleted++
+	}
+	return deleted, errors.Join(errs...)
+}
@@ -0,0 +1,69 @@
+package issues
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+func TestListAndDeleteReports(t *testing.T) {
+	for _, tmpl := range []string{"", "{timestamp}.coadmin_issue", "{app}-{level}-{timestamp}.coadmin_issue"} {
+		t.Run(tmpl, func(t *testing.T) {
+			ri := NewReportIssues("test", WithFolder(t.TempDir()), WithFilenameTemplate(tmpl))
+			var ids []uint32
+			for _, issue := range []string{"first", "second", "third"} {
+				result := ri.AddResult(issue, nil, LevelError, nil)
+				if result.Reason != ResultWrittenToFile {
+					t.Fatalf("Add %q: %s %v", issue, result.Reason, result.Err)
+				}
+				ids = append(ids, result.Report.IssueID)
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			infos, err := ri.ListReports()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(infos) != len(ids) {
+				t.Fatalf("ListReports returned %d files, want %d", len(infos), len(ids))
+			}
+			for i, info := range infos {
+				// Newest first.
+				if want := ids[len(ids)-1-i]; info.IssueID != want {
+					t.Errorf("file %d: IssueID %d, want %d", i, info.IssueID, want)
+				}
+				if info.Size == 0 {
+					t.Errorf("file %d: empty", i)
+				}
+			}
+
+			if err := ri.DeleteReport(ids[1]); err != nil {
+				t.Fatalf("DeleteReport: %v", err)
+			}
+			if err := ri.DeleteReport(ids[1]); !errors.Is(err, fs.ErrNotExist) {
+				t.Fatalf("DeleteReport of a deleted report: %v, want fs.ErrNotExist", err)
+			}
+			deleted, err := ri.DeleteAllReports()
+			if err != nil || deleted != 2 {
+				t.Fatalf("DeleteAllReports: %d, %v, want 2", deleted, err)
+			}
+		})
+	}
+}
+
+func TestFilenameTemplateSuffix(t *testing.T) {
+	for _, tmpl := range []string{"{app}-{timestamp}.json", "{id}.coadmin_issue.bak", "{id}"} {
+		if err := ValidateFilenameTemplate(tmpl); !errors.Is(err, ErrInvalidFilenameTemplate) {
+			t.Errorf("ValidateFilenameTemplate(%q): %v, want ErrInvalidFilenameTemplate", tmpl, err)
+		}
+	}
+
+	ri := NewReportIssues("test", WithFolder(t.TempDir()), WithFilenameTemplate("{app}-{timestamp}.json"))
+	if err := ri.AddE("lost", nil, LevelError, nil); !errors.Is(err, ErrInvalidFilenameTemplate) {
+		t.Fatalf("AddE: %v, want ErrInvalidFilenameTemplate", err)
+	}
+	if infos, err := ri.ListReports(); err != nil || len(infos) != 0 {
+		t.Fatalf("ListReports: %d files, %v, want none", len(infos), err)
+	}
+}
@@ -0,0 +1,46 @@
+package issues
+
+import (
+	"fmt"
+	"strings"
+)
+
+// normalizeTags merges the tag lists into one, lowercased and trimmed, in
+// order of first appearance. Empty and duplicate tags are dropped; nil is
+// returned when no tag is left.
+func normalizeTags(lists ...[]string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, list := range lists {
+		for _, tag := range list {
+			tag = strings.ToLower(strings.TrimSpace(tag))
+			if tag == "" || seen[tag] {
+				continue
+			}
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// parseTags converts the value of OptionTags to a list of tags.
+func parseTags(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case []string:
+		return v, nil
+	case string:
+		return strings.Split(v, ","), nil
+	case []interface{}:
+		tags := make([]string, 0, len(v))
+		for _, tag := range v {
+			s, ok := tag.(string)
+			if !ok {
+				return nil, fmt.Errorf("option %q: unsupported tag type %T", OptionTags, tag)
+			}
+			tags = append(tags, s)
+		}
+		return tags, nil
+	}
+	return nil, fmt.Errorf("option %q: unsupported type %T", OptionTags, value)
+}
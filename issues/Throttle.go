@@ -0,0 +1,150 @@
+package issues
+
+import (
+	"strings"
+	"time"
+)
+
+// throttleKey identifies an issue in the reported map.
+type throttleKey struct {
+	hash  uint64
+	level string
+}
+
+// throttleEntry is the throttle state of an issue in the reported map.
+type throttleEntry struct {
+	nextAllowed     time.Time // when the issue may be reported again
+	suppressed      int       // occurrences throttled since the last report
+	firstSuppressed time.Time // time of the first of them
+}
+
+// interval returns the minimum interval between two reports of the same
+// issue at the given level.
+func (ri *ReportIssues) interval(level string) time.Duration {
+	if interval, ok := ri.Options.LevelIntervals[level]; ok {
+		return interval
+	}
+	return ri.Options.MinimumInterval
+}
+
+// throttle reports whether the issue identified by hash and level was
+// reported too recently, and returns its previous throttle state. If so, the
+// occurrence is counted as suppressed and entry.nextAllowed tells when the
+// issue may be reported again. If not, the next allowed reporting time is
+// recorded interval from now and entry carries the occurrences suppressed
+// since the last report. A zero interval is never throttled.
+func (ri *ReportIssues) throttle(hash uint64, level string, interval time.Duration, now time.Time) (bool, throttleEntry) {
+	if interval <= 0 {
+		return false, throttleEntry{}
+	}
+	key := throttleKey{hash: hash, level: level}
+	ri.Mutex.Lock()
+	defer ri.Mutex.Unlock()
+	if !now.Before(ri.nextSweep) {
+		ri.sweepThrottles(now)
+	}
+	entry, exists := ri.reported[key]
+	if exists && now.Before(entry.nextAllowed) {
+		ri.stats.countThrottled(level)
+		if entry.suppressed == 0 {
+			entry.firstSuppressed = now
+		}
+		entry.suppressed++
+		ri.reported[key] = entry
+		return true, entry
+	}
+	// Set next allowed reporting time.
+	ri.reported[key] = throttleEntry{nextAllowed: now.Add(interval)}
+	ri.scheduleThrottleSave()
+	return false, entry
+}
+
+// sweepThrottles removes the entries whose interval elapsed more than
+// ThrottleRetention ago. It must be called with ri.Mutex held.
+func (ri *ReportIssues) sweepThrottles(now time.Time) {
+	retention := ri.Options.ThrottleRetention
+	cutoff := now.Add(-retention)
+	for key, entry := range ri.reported {
+		if entry.nextAllowed.Before(cutoff) {
+			delete(ri.reported, key)
+		}
+	}
+	ri.nextSweep = now.Add(max(retention, time.Second))
+}
+
+// ThrottleSize returns the number of issues held in the throttle state.
+func (ri *ReportIssues) ThrottleSize() int {
+	ri.Mutex.Lock()
+	defer ri.Mutex.Unlock()
+	return len(ri.reported)
+}
+
+// unthrottle forgets that the issue identified by key was reported.
+func (ri *ReportIssues) unthrottle(key throttleKey) {
+	ri.Mutex.Lock()
+	defer ri.Mutex.Unlock()
+	delete(ri.reported, key)
+	ri.scheduleThrottleSave()
+}
+
+// ResetThrottle forgets that the issue was reported at the given level, so
+// its next occurrence is reported immediately. The issue is normalized as
+// by Add, see normalizeDescription. Use ResetThrottleExtra for issues split
+// by Options.HashExtraKeys.
+func (ri *ReportIssues) ResetThrottle(issue, level string) {
+	ri.ResetThrottleExtra(issue, level, nil)
+}
+
+// ResetThrottleExtra behaves like ResetThrottle for the issue reported with
+// extra, whose Options.HashExtraKeys values are part of its hash.
+func (ri *ReportIssues) ResetThrottleExtra(issue, level string, extra map[string]interface{}) {
+	level = strings.ToLower(strings.TrimSpace(level))
+	issue, _ = ri.normalizeDescription(issue)
+	ri.unthrottle(throttleKey{hash: ri.groupHash(issue, level, extra), level: level})
+}
+
+// ResetAllThrottles forgets every reported issue.
+func (ri *ReportIssues) ResetAllThrottles() {
+	ri.Mutex.Lock()
+	defer ri.Mutex.Unlock()
+	clear(ri.reported)
+	ri.scheduleThrottleSave()
+}
+
+// ClearRateLimit forgets every reported issue, like ResetAllThrottles.
+func (ri *ReportIssues) ClearRateLimit() {
+	ri.ResetAllThrottles()
+}
+
+// ClearRateLimitFor forgets the issue with the given IssueID, so its next
+// occurrence is reported immediately. See ClearRateLimit to clear all.
+// With a 64-bit HashAlgorithm, every issue whose IssueID64 has these lower
+// 32 bits is forgotten.
+func (ri *ReportIssues) ClearRateLimitFor(hash uint32) {
+	ri.Mutex.Lock()
+	defer ri.Mutex.Unlock()
+	for key := range ri.reported {
+		if uint32(key.hash) == hash {
+			delete(ri.reported, key)
+		}
+	}
+	ri.scheduleThrottleSave()
+}
+
+// GetRateLimitExpiry returns when the issue with the given IssueID may be
+// reported again, and false when it is not currently throttled.
+func (ri *ReportIssues) GetRateLimitExpiry(hash uint32) (time.Time, bool) {
+	now := ri.now()
+	ri.Mutex.Lock()
+	defer ri.Mutex.Unlock()
+	var expiry time.Time
+	for key, entry := range ri.reported {
+		if uint32(key.hash) == hash && entry.nextAllowed.After(expiry) {
+			expiry = entry.nextAllowed
+		}
+	}
+	if !now.Before(expiry) {
+		return time.Time{}, false
+	}
+	return expiry, true
+}
@@ -0,0 +1,100 @@
+package issues
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// throttleSaveDelay debounces the saves of Options.ThrottleStateFile.
+const throttleSaveDelay = time.Second
+
+// throttleState is the content of Options.ThrottleStateFile.
+type throttleState struct {
+	Entries []throttleStateEntry `json:"entries"`
+}
+
+// throttleStateEntry is an issue of the throttle state.
+type throttleStateEntry struct {
+	IssueID     uint64 `json:"issue_id"`
+	Level       string `json:"level"`
+	NextAllowed int64  `json:"next_allowed"` // Unix milliseconds
+}
+
+// loadThrottleState fills the reported map from Options.ThrottleStateFile,
+// dropping the entries already expired. A missing or unreadable file is
+// ignored, the throttle state then starts empty.
+func (ri *ReportIssues) loadThrottleState() {
+	data, err := os.ReadFile(ri.Options.ThrottleStateFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			ri.LogDebug("Ignoring throttle state: %v", err)
+		}
+		return
+	}
+	var state throttleState
+	if err := json.Unmarshal(data, &state); err != nil {
+		ri.LogDebug("Ignoring corrupt throttle state %s: %v", ri.Options.ThrottleStateFile, err)
+		return
+	}
+	now := ri.now()
+	ri.Mutex.Lock()
+	defer ri.Mutex.Unlock()
+	for _, entry := range state.Entries {
+		nextAllowed := time.UnixMilli(entry.NextAllowed)
+		if nextAllowed.After(now) {
+			ri.reported[throttleKey{hash: entry.IssueID, level: entry.Level}] = throttleEntry{nextAllowed: nextAllowed}
+		}
+	}
+	ri.LogDebug("Loaded %d throttled issues from %s", len(ri.reported), ri.Options.ThrottleStateFile)
+}
+
+// scheduleThrottleSave saves the throttle state after throttleSaveDelay,
+// unless a save is already pending. It must be called with ri.Mutex held.
+func (ri *ReportIssues) scheduleThrottleSave() {
+	if ri.Options.ThrottleStateFile == "" || ri.saveTimer != nil {
+		return
+	}
+	ri.saveTimer = time.AfterFunc(throttleSaveDelay, func() {
+		if err := ri.saveThrottleState(); err != nil {
+			ri.LogDebug("Saving throttle state: %v", err)
+		}
+	})
+}
+
+// saveThrottleState writes the unexpired entries of the throttle state to
+// Options.ThrottleStateFile, atomically.
+func (ri *ReportIssues) saveThrottleState() error {
+	ri.saveMu.Lock()
+	defer ri.saveMu.Unlock()
+
+	now := ri.now()
+	var state throttleState
+	ri.Mutex.Lock()
+	if ri.saveTimer != nil {
+		ri.saveTimer.Stop()
+		ri.saveTimer = nil
+	}
+	for key, entry := range ri.reported {
+		if entry.nextAllowed.After(now) {
+			state.Entries = append(state.Entries, throttleStateEntry{
+				IssueID:     key.hash,
+				Level:       key.level,
+				NextAllowed: entry.nextAllowed.UnixMilli(),
+			})
+		}
+	}
+	ri.Mutex.Unlock()
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshalling throttle state: %w", err)
+	}
+	file := ri.Options.ThrottleStateFile
+	if err := writeFileAtomic(filepath.Dir(file), file, data, 0600); err != nil {
+		return fmt.Errorf("writing throttle state: %w", err)
+	}
+	return nil
+}
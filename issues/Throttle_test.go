@@ -0,0 +1,189 @@
+package issues
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestResetThrottleNormalizesIssue(t *testing.T) {
+	ri := NewReportIssues("test", WithFolder(t.TempDir()))
+	if !ri.Error("disk  full\n", nil, nil) {
+		t.Fatal("first Error was not reported")
+	}
+	if ri.Error("disk  full\n", nil, nil) {
+		t.Fatal("second Error was not throttled")
+	}
+	ri.ResetThrottle("disk  full\n", "error")
+	if !ri.Error("disk  full\n", nil, nil) {
+		t.Fatal("Error still throttled after ResetThrottle")
+	}
+}
+
+func TestResetThrottleExtra(t *testing.T) {
+	ri := NewReportIssues("test", WithFolder(t.TempDir()), WithHashExtraKeys("host"))
+	db1 := map[string]interface{}{"host": "db1"}
+	db2 := map[string]interface{}{"host": "db2"}
+	if !ri.Error("connection refused", db1, nil) || !ri.Error("connection refused", db2, nil) {
+		t.Fatal("issues split by HashExtraKeys were not both reported")
+	}
+	ri.ResetThrottle("connection refused", "error")
+	if ri.Error("connection refused", db1, nil) {
+		t.Fatal("ResetThrottle without extra reset an issue split by HashExtraKeys")
+	}
+	ri.ResetThrottleExtra("connection refused", "error", db1)
+	if !ri.Error("connection refused", db1, nil) {
+		t.Fatal("Error still throttled after ResetThrottleExtra")
+	}
+	if ri.Error("connection refused", db2, nil) {
+		t.Fatal("ResetThrottleExtra reset the issue of another host")
+	}
+}
+
+func TestThrottleSweep(t *testing.T) {
+	const issues = 100000
+	const interval, retention = time.Minute, 10 * time.Minute
+	ri := NewReportIssues("test", WithThrottleRetention(retention))
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < issues; i++ {
+		hash := ri.groupHash(fmt.Sprintf("user %d not found", i), "error", nil)
+		if throttled, _ := ri.throttle(hash, "error", interval, start); throttled {
+			t.Fatalf("issue %d collided with an earlier one", i)
+		}
+	}
+	if got := ri.ThrottleSize(); got != issues {
+		t.Fatalf("ThrottleSize() = %d, want %d", got, issues)
+	}
+
+	// Within the retention the entries are kept.
+	ri.throttle(1, "error", interval, start.Add(interval+retention/2))
+	if got := ri.ThrottleSize(); got != issues+1 {
+		t.Fatalf("ThrottleSize() = %d within the retention, want %d", got, issues+1)
+	}
+	// Past it they are swept, on the next throttle call.
+	ri.throttle(2, "error", interval, start.Add(interval+retention+time.Second))
+	if got := ri.ThrottleSize(); got != 2 {
+		t.Errorf("ThrottleSize() = %d after expiry, want 2", got)
+	}
+}
+
+func TestClearRateLimit(t *testing.T) {
+	ri := NewReportIssues("test", WithFolder(t.TempDir()))
+	first, _ := ri.AddGet("disk full", nil, LevelError, nil)
+	second, _ := ri.AddGet("cache miss", nil, LevelError, nil)
+	if first == nil || second == nil {
+		t.Fatal("the first occurrences were not reported")
+	}
+	if _, ok := ri.GetRateLimitExpiry(first.IssueID); !ok {
+		t.Fatal("GetRateLimitExpiry() reports the issue as not throttled")
+	}
+
+	ri.ClearRateLimitFor(first.IssueID)
+	if _, ok := ri.GetRateLimitExpiry(first.IssueID); ok {
+		t.Error("the issue is still throttled after ClearRateLimitFor")
+	}
+	if _, ok := ri.GetRateLimitExpiry(second.IssueID); !ok {
+		t.Error("ClearRateLimitFor cleared another issue")
+	}
+
+	ri.ClearRateLimit()
+	if n := ri.ThrottleSize(); n != 0 {
+		t.Errorf("ThrottleSize() = %d after ClearRateLimit, want 0", n)
+	}
+	if !ri.Error("disk full", nil, nil) || !ri.Error("cache miss", nil, nil) {
+		t.Error("issues still throttled after ClearRateLimit")
+	}
+}
+
+func TestThrottlePerLevel(t *testing.T) {
+	ri := NewReportIssues("test", WithFolder(t.TempDir()))
+	if !ri.Add("disk full", nil, LevelError, nil) || !ri.Add("disk full", nil, LevelWarning, nil) {
+		t.Fatal("the same issue at two levels was not reported at both")
+	}
+	if ri.Add("disk full", nil, LevelError, nil) {
+		t.Error("the error was not throttled")
+	}
+	if ri.Add("disk full", nil, LevelWarning, nil) {
+		t.Error("the warning was not throttled")
+	}
+	ri.ResetThrottle("disk full", "warning")
+	if ri.Add("disk full", nil, LevelError, nil) {
+		t.Error("resetting the warning unthrottled the error")
+	}
+	if !ri.Add("disk full", nil, LevelWarning, nil) {
+		t.Error("the warning is still throttled after its reset")
+	}
+}
+
+func TestResetThrottleLetsReportThrough(t *testing.T) {
+	ri := NewReportIssues("test", WithFolder(t.TempDir()))
+	ri.Add("disk full", nil, LevelError, nil)
+	ri.Add("cache miss", nil, LevelWarning, nil)
+	if ri.Add("disk full", nil, LevelError, nil) || ri.Add("cache miss", nil, LevelWarning, nil) {
+		t.Fatal("the second occurrences were not throttled")
+	}
+
+	ri.ResetThrottle("disk full", "error")
+	if !ri.Add("disk full", nil, LevelError, nil) {
+		t.Error("the issue is still throttled after ResetThrottle")
+	}
+	if ri.Add("cache miss", nil, LevelWarning, nil) {
+		t.Error("ResetThrottle unthrottled another issue")
+	}
+
+	ri.ResetAllThrottles()
+	if !ri.Add("disk full", nil, LevelError, nil) || !ri.Add("cache miss", nil, LevelWarning, nil) {
+		t.Error("issues still throttled after ResetAllThrottles")
+	}
+	files, err := ri.ListReports()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Errorf("got %d report files, want one per issue", len(files))
+	}
+}
+
+func TestOptionIntervalNumbers(t *testing.T) {
+	for _, tc := range []struct {
+		value interface{}
+		want  time.Duration
+	}{
+		{90 * time.Second, 90 * time.Second},
+		{"1m30s", 90 * time.Second},
+		{float64(1.5), 1500 * time.Millisecond},
+		{float32(2), 2 * time.Second},
+		{30, 30 * time.Second},
+		{int32(30), 30 * time.Second},
+		{int64(30), 30 * time.Second},
+		{0, 0},
+	} {
+		ro, rest, err := parseReportOptions(map[string]interface{}{OptionInterval: tc.value, "user": "bob"})
+		if err != nil {
+			t.Fatalf("%T %v: %v", tc.value, tc.value, err)
+		}
+		if !ro.hasInterval || ro.interval != tc.want {
+			t.Errorf("%T %v: interval %v, want %v", tc.value, tc.value, ro.interval, tc.want)
+		}
+		if _, ok := rest[OptionInterval]; ok || rest["user"] != "bob" {
+			t.Errorf("%T %v: options left %v", tc.value, tc.value, rest)
+		}
+	}
+	if _, _, err := parseReportOptions(map[string]interface{}{OptionInterval: true}); err == nil {
+		t.Error("a bool interval was accepted")
+	}
+}
+
+func TestOptionIntervalZeroNeverThrottles(t *testing.T) {
+	ri := NewReportIssues("test", WithFolder(t.TempDir()))
+	// float64(0) is what encoding/json decodes "interval": 0 into.
+	options := map[string]interface{}{OptionInterval: float64(0)}
+	for i := 0; i < 3; i++ {
+		if !ri.Error("disk full", nil, options) {
+			t.Fatalf("Error %d with interval 0 was throttled", i)
+		}
+	}
+	if got := ri.GetStats().TotalThrottled; got != 0 {
+		t.Fatalf("TotalThrottled = %d, want 0", got)
+	}
+}
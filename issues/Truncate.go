@@ -0,0 +1,147 @@
+package issues
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"unicode/utf8"
+)
+
+// MetaTruncated is the meta key set to "true" on reports shrunk to fit
+// Options.MaxReportBytes.
+const MetaTruncated = "truncated"
+
+// truncate shrinks report until its JSON fits Options.MaxReportBytes: the
+// Extra then the Options values are cut largest first, strings keeping their
+// beginning and other values being replaced by a placeholder, then the
+// StackTrace is dropped and the Meta values are cut. As a last resort Extra,
+// Options and Meta are dropped and finally the Description is cut. The report
+// is never dropped.
+func (ri *ReportIssues) truncate(report *Report) {
+	limit := ri.Options.MaxReportBytes
+	if limit <= 0 {
+		return
+	}
+	size := reportSize(report)
+	if size <= limit {
+		return
+	}
+	original := size
+
+	// Meta, Options and Extra may be shared with the caller, see AddReport.
+	report.Meta = maps.Clone(report.Meta)
+	if report.Meta == nil {
+		report.Meta = make(map[string]string, 1)
+	}
+	report.Meta[MetaTruncated] = "true"
+	report.Extra = maps.Clone(report.Extra)
+	report.Options = maps.Clone(report.Options)
+	size = reportSize(report)
+
+	size = shrinkValues(report, report.Extra, size, limit)
+	size = shrinkValues(report, report.Options, size, limit)
+	if size > limit && report.StackTrace != nil {
+		report.StackTrace = nil
+		size = reportSize(report)
+	}
+	metaKeys := make([]string, 0, len(report.Meta))
+	for key := range report.Meta {
+		metaKeys = append(metaKeys, key)
+	}
+	slices.SortFunc(metaKeys, func(a, b string) int { return len(report.Meta[b]) - len(report.Meta[a]) })
+	for _, key := range metaKeys {
+		if size <= limit {
+			break
+		}
+		if key != MetaTruncated {
+			size = cutValue(report, report.Meta[key], size, limit, func(s string) { report.Meta[key] = s })
+		}
+	}
+	if size > limit {
+		report.Extra, report.Options = nil, nil
+		report.Meta = map[string]string{MetaTruncated: "true"}
+		size = reportSize(report)
+	}
+	if size > limit {
+		size = cutValue(report, report.Description, size, limit, func(s string) { report.Description = s })
+	}
+	ri.LogDebug("Report %d truncated from %d to %d bytes (limit %d)", report.IssueID, original, size, limit)
+}
+
+// shrinkValues cuts the values of m, largest first, until report fits limit,
+// and returns the new size of report.
+func shrinkValues(report *Report, m map[string]interface{}, size, limit int) int {
+	sizes := make(map[string]int, len(m))
+	keys := make([]string, 0, len(m))
+	for key, value := range m {
+		sizes[key] = jsonSize(value)
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b string) int { return sizes[b] - sizes[a] })
+	for _, key := range keys {
+		if size <= limit {
+			break
+		}
+		s, ok := m[key].(string)
+		if !ok {
+			m[key] = fmt.Sprintf("[truncated %d bytes]", sizes[key])
+			size = reportSize(report)
+			continue
+		}
+		size = cutValue(report, s, size, limit, func(cut string) { m[key] = cut })
+	}
+	return size
+}
+
+// cutValue shortens original, stored into report by set, until report fits
+// limit or nothing of original is left, and returns the new size of report.
+// Every attempt cuts original itself, so the suffix telling how many bytes
+// were removed appears once.
+func cutValue(report *Report, original string, size, limit int, set func(string)) int {
+	if original == "" {
+		return size
+	}
+	keep := len(original)
+	// Escaping may make the encoded value longer than original, scale the
+	// bytes to remove accordingly.
+	encoded := max(jsonSize(original)-2, 1)
+	for size > limit {
+		keep -= (size - limit + 32) * len(original) / encoded // room for the suffix
+		if keep <= 0 {
+			set(fmt.Sprintf("[truncated %d bytes]", len(original)))
+			return reportSize(report)
+		}
+		kept := cutString(original, keep)
+		keep = len(kept)
+		set(kept + fmt.Sprintf("...[truncated %d bytes]", len(original)-keep))
+		size = reportSize(report)
+	}
+	return size
+}
+
+// reportSize returns the length of the JSON encoding of report.
+func reportSize(report *Report) int {
+	return jsonSize(report)
+}
+
+// jsonSize returns the length of the JSON encoding of v, zero when it
+// cannot be marshalled.
+func jsonSize(v interface{}) int {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return 0
+	}
+	return len(data)
+}
+
+// cutString returns at most n bytes of s, without splitting a rune.
+func cutString(s string, n int) string {
+	if n >= len(s) {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,89 @@
+package issues
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// truncated runs truncate on report with limit and checks that the result
+// is valid JSON of at most limit bytes.
+func truncated(t *testing.T, limit int, report Report) Report {
+	t.Helper()
+	ri := NewReportIssues("test", WithMaxReportBytes(limit))
+	ri.truncate(&report)
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) > limit {
+		t.Fatalf("report is %d bytes, want at most %d", len(data), limit)
+	}
+	var decoded Report
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("truncated report is not valid JSON: %v", err)
+	}
+	if decoded.Meta[MetaTruncated] != "true" {
+		t.Errorf("Meta[%q] = %q, want \"true\"", MetaTruncated, decoded.Meta[MetaTruncated])
+	}
+	return decoded
+}
+
+func TestTruncateExtra(t *testing.T) {
+	const limit = 1024
+	extra := map[string]interface{}{
+		// Every '<' takes six bytes once escaped, which the cut must
+		// account for to keep the beginning of the value.
+		"html":  strings.Repeat("<", 10*limit),
+		"count": 3,
+	}
+	report := truncated(t, limit, Report{Description: "disk full", Extra: extra})
+	html, _ := report.Extra["html"].(string)
+	if !strings.HasPrefix(html, "<") || strings.Count(html, "[truncated") != 1 {
+		t.Errorf("Extra[\"html\"] = %q, want its beginning and one suffix", html)
+	}
+	if report.Extra["count"] != float64(3) || report.Description != "disk full" {
+		t.Errorf("small fields were cut: %v %q", report.Extra["count"], report.Description)
+	}
+	if len(extra["html"].(string)) != 10*limit {
+		t.Error("the caller's Extra was modified")
+	}
+}
+
+func TestTruncateMetaAndOptions(t *testing.T) {
+	const limit = 1024
+	report := truncated(t, limit, Report{
+		Description: "disk full",
+		Meta:        map[string]string{"hostname": "db1", "env_dump": strings.Repeat("m", 4*limit)},
+		Options:     map[string]interface{}{"context": strings.Repeat("o", 4*limit)},
+	})
+	if report.Meta["hostname"] != "db1" {
+		t.Errorf("Meta[\"hostname\"] = %q, want \"db1\"", report.Meta["hostname"])
+	}
+	for _, value := range []string{report.Meta["env_dump"], report.Options["context"].(string)} {
+		if strings.Count(value, "[truncated") != 1 {
+			t.Errorf("value %q, want one truncation suffix", value)
+		}
+	}
+}
+
+func TestTruncateDropsMaps(t *testing.T) {
+	const limit = 512
+	extra := make(map[string]interface{})
+	for i := 0; i < 100; i++ {
+		extra[fmt.Sprintf("key%03d", i)] = []int{i, i, i}
+	}
+	report := truncated(t, limit, Report{Description: "disk full", Extra: extra})
+	if report.Extra != nil || report.Description != "disk full" {
+		t.Errorf("Extra %v, Description %q: want Extra dropped before the Description is cut", report.Extra, report.Description)
+	}
+}
+
+func TestTruncateDescription(t *testing.T) {
+	const limit = 512
+	report := truncated(t, limit, Report{Description: strings.Repeat("é", limit)})
+	if !strings.HasPrefix(report.Description, "é") || strings.Count(report.Description, "[truncated") != 1 {
+		t.Errorf("Description = %q, want its beginning and one suffix", report.Description)
+	}
+}
@@ -0,0 +1,54 @@
+package issues
+
+import (
+	"runtime/debug"
+	"sync"
+)
+
+// modulePath is the module this package belongs to, as listed in build info.
+const modulePath = "github.com/7c/coadmin-golib"
+
+// fallbackVersion is reported when build info carries no module version,
+// e.g. for stripped binaries or "go run". Keep it in sync with version.txt.
+const fallbackVersion = "v0.0.10"
+
+// BuildVersion overrides the library version reported in LibVersion when set,
+// e.g. with -ldflags "-X github.com/7c/coadmin-golib/issues.BuildVersion=v1.2.3".
+var BuildVersion string
+
+// buildInfoVersion caches the module version read from build info.
+var buildInfoVersion = sync.OnceValue(func() string {
+	return moduleVersion(debug.ReadBuildInfo())
+})
+
+// moduleVersion returns the version of modulePath in info, the main module
+// or a dependency, and fallbackVersion when info is unavailable (ok false)
+// or carries no version.
+func moduleVersion(info *debug.BuildInfo, ok bool) string {
+	if !ok {
+		return fallbackVersion
+	}
+	if info.Main.Path == modulePath && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			if dep.Replace != nil && dep.Replace.Version != "" {
+				return dep.Replace.Version
+			}
+			if dep.Version != "" {
+				return dep.Version
+			}
+		}
+	}
+	return fallbackVersion
+}
+
+// Version returns the library version: BuildVersion when set, otherwise the
+// module version from build info, falling back to the compiled-in version.
+func Version() string {
+	if BuildVersion != "" {
+		return BuildVersion
+	}
+	return buildInfoVersion()
+}
@@ -0,0 +1,48 @@
+package issues
+
+import (
+	"context"
+	"runtime/debug"
+	"testing"
+)
+
+func TestBuildVersion(t *testing.T) {
+	defer func(version string) { BuildVersion = version }(BuildVersion)
+	BuildVersion = "v1.2.3"
+	ri := NewReportIssues("test")
+	report, _, _ := ri.generate(context.Background(), "versioned", "", nil, "error", nil, reportOptions{})
+	if report.LibVersion != "v1.2.3" {
+		t.Errorf("LibVersion = %q, want v1.2.3", report.LibVersion)
+	}
+	BuildVersion = ""
+	report, _, _ = ri.generate(context.Background(), "unversioned", "", nil, "error", nil, reportOptions{})
+	if report.LibVersion != buildInfoVersion() {
+		t.Errorf("LibVersion = %q without BuildVersion, want %q", report.LibVersion, buildInfoVersion())
+	}
+}
+
+func TestModuleVersion(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		info *debug.BuildInfo
+		ok   bool
+		want string
+	}{
+		{"no build info", nil, false, fallbackVersion},
+		{"main module", &debug.BuildInfo{Main: debug.Module{Path: modulePath, Version: "v0.1.0"}}, true, "v0.1.0"},
+		{"devel main module", &debug.BuildInfo{Main: debug.Module{Path: modulePath, Version: "(devel)"}}, true, fallbackVersion},
+		{"dependency", &debug.BuildInfo{
+			Main: debug.Module{Path: "example.com/app", Version: "v2.0.0"},
+			Deps: []*debug.Module{{Path: "example.com/other", Version: "v9.9.9"}, {Path: modulePath, Version: "v0.2.0"}},
+		}, true, "v0.2.0"},
+		{"replaced dependency", &debug.BuildInfo{
+			Main: debug.Module{Path: "example.com/app"},
+			Deps: []*debug.Module{{Path: modulePath, Version: "v0.2.0", Replace: &debug.Module{Path: "../fork", Version: "v0.2.1"}}},
+		}, true, "v0.2.1"},
+		{"stripped", &debug.BuildInfo{Main: debug.Module{Path: "example.com/app"}}, true, fallbackVersion},
+	} {
+		if got := moduleVersion(tt.info, tt.ok); got != tt.want {
+			t.Errorf("%s: moduleVersion() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
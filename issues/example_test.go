@@ -0,0 +1,16 @@
+package issues_test
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/7c/coadmin-golib/issues"
+)
+
+// The library events, e.g. a report written or throttled, become debug
+// records carrying the app, issue_id, level and action attributes.
+func ExampleWithSlogLogger() {
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	ri := issues.NewReportIssues("myapp", issues.WithSlogLogger(logger))
+	ri.Add("disk full", map[string]interface{}{"free": 0}, issues.LevelError, nil)
+}
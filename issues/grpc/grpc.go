@@ -0,0 +1,74 @@
+// Package grpc delivers the live submissions of a ReportIssues over gRPC,
+// with the ReportService of report.proto, instead of HTTP:
+//
+//	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(creds))
+//	ri := issues.NewReportIssues("myapp",
+//		issues.WithLive(true),
+//		issues.WithBackend(issuesgrpc.NewGRPCBackend(conn)),
+//	)
+//
+// The reports travel as the coadmin.report.v1.Report message of the
+// issues/proto package, see issues.ReportToProto and issues.ProtoToReport.
+// With a BatchSize above one the batches are sent over the SubmitStream RPC.
+// Servers implement ReportServiceServer, see RegisterReportServiceServer.
+package grpc
+
+//go:generate protoc -I../.. --go_out=../.. --go_opt=paths=source_relative --go-grpc_out=../.. --go-grpc_opt=paths=source_relative issues/grpc/report.proto
+
+import (
+	"context"
+
+	"github.com/7c/coadmin-golib/issues"
+	issuesproto "github.com/7c/coadmin-golib/issues/proto"
+	"google.golang.org/grpc"
+)
+
+// GRPCBackend is an issues.Backend calling the ReportService.
+type GRPCBackend struct {
+	client ReportServiceClient
+}
+
+var _ issues.BulkBackend = (*GRPCBackend)(nil)
+
+// NewGRPCBackend returns a GRPCBackend calling the ReportService over conn.
+func NewGRPCBackend(conn grpc.ClientConnInterface) *GRPCBackend {
+	return &GRPCBackend{client: NewReportServiceClient(conn)}
+}
+
+// Submit sends the submission with the Submit RPC.
+func (b *GRPCBackend) Submit(ctx context.Context, submission issues.ReportSubmission) error {
+	in, err := newSubmission(submission.Issue)
+	if err != nil {
+		return err
+	}
+	_, err = b.client.Submit(ctx, in)
+	return err
+}
+
+// SubmitBulk sends the reports of the submission with the SubmitStream RPC.
+func (b *GRPCBackend) SubmitBulk(ctx context.Context, submission issues.BulkReportSubmission) error {
+	stream, err := b.client.SubmitStream(ctx)
+	if err != nil {
+		return err
+	}
+	for _, report := range submission.Issues {
+		in, err := newSubmission(report)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(in); err != nil {
+			return err
+		}
+	}
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+// newSubmission converts report into a ReportSubmission message.
+func newSubmission(report issues.Report) (*issuesproto.ReportSubmission, error) {
+	issue, err := issues.ReportToProto(report)
+	if err != nil {
+		return nil, err
+	}
+	return &issuesproto.ReportSubmission{Issue: issue}, nil
+}
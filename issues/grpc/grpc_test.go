@@ -0,0 +1,124 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/7c/coadmin-golib/issues"
+	issuesproto "github.com/7c/coadmin-golib/issues/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// recordingServer records the reports it receives.
+type recordingServer struct {
+	UnimplementedReportServiceServer
+
+	mu      sync.Mutex
+	reports []issues.Report
+}
+
+func (s *recordingServer) record(in *issuesproto.ReportSubmission) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reports = append(s.reports, issues.ProtoToReport(in.GetIssue()))
+}
+
+func (s *recordingServer) Submit(ctx context.Context, in *issuesproto.ReportSubmission) (*ReportAck, error) {
+	s.record(in)
+	return &ReportAck{Accepted: 1}, nil
+}
+
+func (s *recordingServer) SubmitStream(stream ReportService_SubmitStreamServer) error {
+	var accepted uint32
+	for {
+		in, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(&ReportAck{Accepted: accepted})
+		}
+		if err != nil {
+			return err
+		}
+		s.record(in)
+		accepted++
+	}
+}
+
+// newBackend serves srv over an in-memory listener and returns a GRPCBackend
+// connected to it.
+func newBackend(t *testing.T, srv ReportServiceServer) *GRPCBackend {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	RegisterReportServiceServer(s, srv)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return NewGRPCBackend(conn)
+}
+
+func TestSubmit(t *testing.T) {
+	srv := &recordingServer{}
+	backend := newBackend(t, srv)
+	report := issues.Report{
+		IssueID:     42,
+		App:         "myapp",
+		Description: "disk full",
+		Level:       "error",
+		Extra:       map[string]interface{}{"free": 0.0},
+		Tags:        []string{"disk"},
+	}
+	if err := backend.Submit(context.Background(), issues.ReportSubmission{Issue: report}); err != nil {
+		t.Fatal(err)
+	}
+	if len(srv.reports) != 1 {
+		t.Fatalf("got %d reports, want 1", len(srv.reports))
+	}
+	got := srv.reports[0]
+	if got.IssueID != 42 || got.App != "myapp" || got.Description != "disk full" ||
+		got.Level != "error" || got.Extra["free"] != 0.0 || len(got.Tags) != 1 {
+		t.Errorf("got %+v, want %+v", got, report)
+	}
+}
+
+func TestSubmitBulk(t *testing.T) {
+	srv := &recordingServer{}
+	backend := newBackend(t, srv)
+	submission := issues.BulkReportSubmission{Issues: []issues.Report{
+		{IssueID: 1, Description: "first"},
+		{IssueID: 2, Description: "second"},
+		{IssueID: 3, Description: "third"},
+	}}
+	if err := backend.SubmitBulk(context.Background(), submission); err != nil {
+		t.Fatal(err)
+	}
+	if len(srv.reports) != 3 {
+		t.Fatalf("got %d reports, want 3", len(srv.reports))
+	}
+	for i, r := range srv.reports {
+		if r.IssueID != submission.Issues[i].IssueID || r.Description != submission.Issues[i].Description {
+			t.Errorf("report %d = %+v, want %+v", i, r, submission.Issues[i])
+		}
+	}
+}
+
+func TestSubmitUnimplemented(t *testing.T) {
+	backend := newBackend(t, UnimplementedReportServiceServer{})
+	if err := backend.Submit(context.Background(), issues.ReportSubmission{}); err == nil {
+		t.Error("Submit to an unimplemented server succeeded")
+	}
+}
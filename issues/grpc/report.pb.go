@@ -0,0 +1,164 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: issues/grpc/report.proto
+
+package grpc
+
+import (
+	proto "github.com/7c/coadmin-golib/issues/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ReportAck acknowledges the reports received.
+type ReportAck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Accepted uint32 `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+}
+
+func (x *ReportAck) Reset() {
+	*x = ReportAck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_issues_grpc_report_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportAck) ProtoMessage() {}
+
+func (x *ReportAck) ProtoReflect() protoreflect.Message {
+	mi := &file_issues_grpc_report_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportAck.ProtoReflect.Descriptor instead.
+func (*ReportAck) Descriptor() ([]byte, []int) {
+	return file_issues_grpc_report_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReportAck) GetAccepted() uint32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+var File_issues_grpc_report_proto protoreflect.FileDescriptor
+
+var file_issues_grpc_report_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x69, 0x73, 0x73, 0x75, 0x65, 0x73, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x63, 0x6f, 0x61, 0x64,
+	0x6d, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x1a, 0x19, 0x69, 0x73, 0x73, 0x75, 0x65, 0x73, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x27, 0x0a, 0x09, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x41, 0x63, 0x6b, 0x12, 0x1a,
+	0x0a, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x32, 0xa3, 0x01, 0x0a, 0x0d, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x44, 0x0a, 0x06,
+	0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x12, 0x23, 0x2e, 0x63, 0x6f, 0x61, 0x64, 0x6d, 0x69, 0x6e,
+	0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x15, 0x2e, 0x63, 0x6f,
+	0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x41,
+	0x63, 0x6b, 0x12, 0x4c, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x12, 0x23, 0x2e, 0x63, 0x6f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x75, 0x62,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x1a, 0x15, 0x2e, 0x63, 0x6f, 0x61, 0x64, 0x6d, 0x69,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x41, 0x63, 0x6b, 0x28, 0x01,
+	0x42, 0x29, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x37,
+	0x63, 0x2f, 0x63, 0x6f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2d, 0x67, 0x6f, 0x6c, 0x69, 0x62, 0x2f,
+	0x69, 0x73, 0x73, 0x75, 0x65, 0x73, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_issues_grpc_report_proto_rawDescOnce sync.Once
+	file_issues_grpc_report_proto_rawDescData = file_issues_grpc_report_proto_rawDesc
+)
+
+func file_issues_grpc_report_proto_rawDescGZIP() []byte {
+	file_issues_grpc_report_proto_rawDescOnce.Do(func() {
+		file_issues_grpc_report_proto_rawDescData = protoimpl.X.CompressGZIP(file_issues_grpc_report_proto_rawDescData)
+	})
+	return file_issues_grpc_report_proto_rawDescData
+}
+
+var file_issues_grpc_report_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_issues_grpc_report_proto_goTypes = []any{
+	(*ReportAck)(nil),              // 0: coadmin.v1.ReportAck
+	(*proto.ReportSubmission)(nil), // 1: coadmin.report.v1.ReportSubmission
+}
+var file_issues_grpc_report_proto_depIdxs = []int32{
+	1, // 0: coadmin.v1.ReportService.Submit:input_type -> coadmin.report.v1.ReportSubmission
+	1, // 1: coadmin.v1.ReportService.SubmitStream:input_type -> coadmin.report.v1.ReportSubmission
+	0, // 2: coadmin.v1.ReportService.Submit:output_type -> coadmin.v1.ReportAck
+	0, // 3: coadmin.v1.ReportService.SubmitStream:output_type -> coadmin.v1.ReportAck
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_issues_grpc_report_proto_init() }
+func file_issues_grpc_report_proto_init() {
+	if File_issues_grpc_report_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_issues_grpc_report_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*ReportAck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_issues_grpc_report_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_issues_grpc_report_proto_goTypes,
+		DependencyIndexes: file_issues_grpc_report_proto_depIdxs,
+		MessageInfos:      file_issues_grpc_report_proto_msgTypes,
+	}.Build()
+	File_issues_grpc_report_proto = out.File
+	file_issues_grpc_report_proto_rawDesc = nil
+	file_issues_grpc_report_proto_goTypes = nil
+	file_issues_grpc_report_proto_depIdxs = nil
+}
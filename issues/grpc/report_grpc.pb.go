@@ -0,0 +1,166 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: issues/grpc/report.proto
+
+package grpc
+
+import (
+	context "context"
+	proto "github.com/7c/coadmin-golib/issues/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ReportService_Submit_FullMethodName       = "/coadmin.v1.ReportService/Submit"
+	ReportService_SubmitStream_FullMethodName = "/coadmin.v1.ReportService/SubmitStream"
+)
+
+// ReportServiceClient is the client API for ReportService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ReportService receives the reports of issues.ReportIssues, each
+// ReportSubmission carrying one coadmin.report.v1.Report.
+type ReportServiceClient interface {
+	// Submit receives one report.
+	Submit(ctx context.Context, in *proto.ReportSubmission, opts ...grpc.CallOption) (*ReportAck, error)
+	// SubmitStream receives a batch of reports, acknowledged once the client
+	// closes the stream.
+	SubmitStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[proto.ReportSubmission, ReportAck], error)
+}
+
+type reportServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReportServiceClient(cc grpc.ClientConnInterface) ReportServiceClient {
+	return &reportServiceClient{cc}
+}
+
+func (c *reportServiceClient) Submit(ctx context.Context, in *proto.ReportSubmission, opts ...grpc.CallOption) (*ReportAck, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportAck)
+	err := c.cc.Invoke(ctx, ReportService_Submit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reportServiceClient) SubmitStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[proto.ReportSubmission, ReportAck], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ReportService_ServiceDesc.Streams[0], ReportService_SubmitStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[proto.ReportSubmission, ReportAck]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ReportService_SubmitStreamClient = grpc.ClientStreamingClient[proto.ReportSubmission, ReportAck]
+
+// ReportServiceServer is the server API for ReportService service.
+// All implementations must embed UnimplementedReportServiceServer
+// for forward compatibility.
+//
+// ReportService receives the reports of issues.ReportIssues, each
+// ReportSubmission carrying one coadmin.report.v1.Report.
+type ReportServiceServer interface {
+	// Submit receives one report.
+	Submit(context.Context, *proto.ReportSubmission) (*ReportAck, error)
+	// SubmitStream receives a batch of reports, acknowledged once the client
+	// closes the stream.
+	SubmitStream(grpc.ClientStreamingServer[proto.ReportSubmission, ReportAck]) error
+	mustEmbedUnimplementedReportServiceServer()
+}
+
+// UnimplementedReportServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReportServiceServer struct{}
+
+func (UnimplementedReportServiceServer) Submit(context.Context, *proto.ReportSubmission) (*ReportAck, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Submit not implemented")
+}
+func (UnimplementedReportServiceServer) SubmitStream(grpc.ClientStreamingServer[proto.ReportSubmission, ReportAck]) error {
+	return status.Errorf(codes.Unimplemented, "method SubmitStream not implemented")
+}
+func (UnimplementedReportServiceServer) mustEmbedUnimplementedReportServiceServer() {}
+func (UnimplementedReportServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeReportServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReportServiceServer will
+// result in compilation errors.
+type UnsafeReportServiceServer interface {
+	mustEmbedUnimplementedReportServiceServer()
+}
+
+func RegisterReportServiceServer(s grpc.ServiceRegistrar, srv ReportServiceServer) {
+	// If the following call pancis, it indicates UnimplementedReportServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ReportService_ServiceDesc, srv)
+}
+
+func _ReportService_Submit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(proto.ReportSubmission)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportServiceServer).Submit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReportService_Submit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportServiceServer).Submit(ctx, req.(*proto.ReportSubmission))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReportService_SubmitStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ReportServiceServer).SubmitStream(&grpc.GenericServerStream[proto.ReportSubmission, ReportAck]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ReportService_SubmitStreamServer = grpc.ClientStreamingServer[proto.ReportSubmission, ReportAck]
+
+// ReportService_ServiceDesc is the grpc.ServiceDesc for ReportService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReportService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "coadmin.v1.ReportService",
+	HandlerType: (*ReportServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Submit",
+			Handler:    _ReportService_Submit_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubmitStream",
+			Handler:       _ReportService_SubmitStream_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "issues/grpc/report.proto",
+}
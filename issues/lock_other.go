@@ -0,0 +1,24 @@
+//go:build !unix
+
+package issues
+
+import (
+	"os"
+	"sync"
+)
+
+// ndjsonMu stands in for the file lock where flock is not available, it
+// only protects the writers of this process.
+var ndjsonMu sync.Mutex
+
+// lockFile takes the process-wide ndjsonMu.
+func lockFile(f *os.File) error {
+	ndjsonMu.Lock()
+	return nil
+}
+
+// unlockFile releases ndjsonMu.
+func unlockFile(f *os.File) error {
+	ndjsonMu.Unlock()
+	return nil
+}
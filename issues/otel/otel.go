@@ -0,0 +1,32 @@
+// Package otel adds the OpenTelemetry trace and span IDs found in the context
+// to the meta of reports. Importing it registers the extractor:
+//
+//	import _ "github.com/7c/coadmin-golib/issues/otel"
+//
+// after which AddWithContext and the other context-aware methods record
+// "trace_id" and "span_id" whenever ctx carries a valid span context.
+package otel
+
+import (
+	"context"
+
+	"github.com/7c/coadmin-golib/issues"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func init() {
+	issues.RegisterContextMeta(Meta)
+}
+
+// Meta returns the trace and span IDs of the span context in ctx, or nil
+// when there is none.
+func Meta(ctx context.Context) map[string]string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return map[string]string{
+		"trace_id": sc.TraceID().String(),
+		"span_id":  sc.SpanID().String(),
+	}
+}
@@ -0,0 +1,29 @@
+package otel
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestMeta(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	valid := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID})
+	ctx := trace.ContextWithSpanContext(context.Background(), valid)
+
+	meta := Meta(ctx)
+	if meta["trace_id"] != "4bf92f3577b34da6a3ce929d0e0e4736" || meta["span_id"] != "00f067aa0ba902b7" {
+		t.Fatalf("Meta with a valid span context = %v", meta)
+	}
+
+	// A trace ID without a span ID is not a valid span context.
+	invalid := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID})
+	if meta := Meta(trace.ContextWithSpanContext(context.Background(), invalid)); meta != nil {
+		t.Fatalf("Meta with an invalid span context = %v, want nil", meta)
+	}
+	if meta := Meta(context.Background()); meta != nil {
+		t.Fatalf("Meta without a span context = %v, want nil", meta)
+	}
+}
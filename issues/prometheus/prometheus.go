@@ -0,0 +1,74 @@
+// Package prometheus exports the counters of a ReportIssues as Prometheus
+// metrics, read from GetStats on every scrape:
+//
+//	prometheus.RegisterMetrics(ri, promclient.DefaultRegisterer)
+package prometheus
+
+import (
+	"github.com/7c/coadmin-golib/issues"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector is a prometheus.Collector exposing the stats of a ReportIssues.
+// The app label is constant, so collectors of reporters with different
+// AppName can be registered side by side.
+type Collector struct {
+	ri        *issues.ReportIssues
+	added     *prometheus.Desc
+	throttled *prometheus.Desc
+	submitted *prometheus.Desc
+	failed    *prometheus.Desc
+	bufferLen *prometheus.Desc
+}
+
+// NewCollector returns a Collector for ri.
+func NewCollector(ri *issues.ReportIssues) *Collector {
+	labels := prometheus.Labels{"app": ri.AppName}
+	return &Collector{
+		ri: ri,
+		added: prometheus.NewDesc("coadmin_reports_added_total",
+			"Reports generated, i.e. not throttled or filtered.", []string{"level"}, labels),
+		throttled: prometheus.NewDesc("coadmin_reports_throttled_total",
+			"Issues suppressed by throttling.", []string{"level"}, labels),
+		submitted: prometheus.NewDesc("coadmin_reports_submitted_total",
+			"Reports accepted by the server.", nil, labels),
+		failed: prometheus.NewDesc("coadmin_reports_failed_total",
+			"Failed submissions and file writes.", nil, labels),
+		bufferLen: prometheus.NewDesc("coadmin_buffer_len",
+			"Reports waiting in the live buffer.", nil, labels),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.added
+	ch <- c.throttled
+	ch <- c.submitted
+	ch <- c.failed
+	ch <- c.bufferLen
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.ri.GetStats()
+	for _, level := range issues.Levels {
+		ch <- prometheus.MustNewConstMetric(c.added, prometheus.CounterValue,
+			float64(stats.AddedByLevel[string(level)]), string(level))
+		ch <- prometheus.MustNewConstMetric(c.throttled, prometheus.CounterValue,
+			float64(stats.ThrottledByLevel[string(level)]), string(level))
+	}
+	ch <- prometheus.MustNewConstMetric(c.submitted, prometheus.CounterValue, float64(stats.TotalSubmitted))
+	ch <- prometheus.MustNewConstMetric(c.failed, prometheus.CounterValue, float64(stats.TotalFailed))
+	ch <- prometheus.MustNewConstMetric(c.bufferLen, prometheus.GaugeValue, float64(stats.BufferLen))
+}
+
+// RegisterMetrics registers a Collector for ri with reg.
+func RegisterMetrics(ri *issues.ReportIssues, reg prometheus.Registerer) error {
+	return reg.Register(NewCollector(ri))
+}
+
+// UnregisterMetrics removes the Collector registered for ri from reg. It
+// reports whether a collector was removed.
+func UnregisterMetrics(ri *issues.ReportIssues, reg prometheus.Registerer) bool {
+	return reg.Unregister(NewCollector(ri))
+}
@@ -0,0 +1,67 @@
+package prometheus
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/7c/coadmin-golib/issues"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	ri := issues.NewReportIssues("billing", issues.WithFolder(t.TempDir()))
+	ri.Error("disk full", nil, nil)
+	ri.Error("disk full", nil, nil)
+	ri.Warning("slow query", nil, nil)
+
+	expected := `
+# HELP coadmin_reports_added_total Reports generated, i.e. not throttled or filtered.
+# TYPE coadmin_reports_added_total counter
+coadmin_reports_added_total{app="billing",level="debug"} 0
+coadmin_reports_added_total{app="billing",level="error"} 1
+coadmin_reports_added_total{app="billing",level="fatal"} 0
+coadmin_reports_added_total{app="billing",level="info"} 0
+coadmin_reports_added_total{app="billing",level="warning"} 1
+# HELP coadmin_reports_throttled_total Issues suppressed by throttling.
+# TYPE coadmin_reports_throttled_total counter
+coadmin_reports_throttled_total{app="billing",level="debug"} 0
+coadmin_reports_throttled_total{app="billing",level="error"} 1
+coadmin_reports_throttled_total{app="billing",level="fatal"} 0
+coadmin_reports_throttled_total{app="billing",level="info"} 0
+coadmin_reports_throttled_total{app="billing",level="warning"} 0
+# HELP coadmin_reports_submitted_total Reports accepted by the server.
+# TYPE coadmin_reports_submitted_total counter
+coadmin_reports_submitted_total{app="billing"} 0
+# HELP coadmin_reports_failed_total Failed submissions and file writes.
+# TYPE coadmin_reports_failed_total counter
+coadmin_reports_failed_total{app="billing"} 0
+# HELP coadmin_buffer_len Reports waiting in the live buffer.
+# TYPE coadmin_buffer_len gauge
+coadmin_buffer_len{app="billing"} 0
+`
+	if err := testutil.CollectAndCompare(NewCollector(ri), strings.NewReader(expected)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRegisterMetrics(t *testing.T) {
+	ri := issues.NewReportIssues("billing", issues.WithFolder(t.TempDir()))
+	reg := prometheus.NewRegistry()
+	if err := RegisterMetrics(ri, reg); err != nil {
+		t.Fatal(err)
+	}
+	// A second reporter of another app registers side by side.
+	if err := RegisterMetrics(issues.NewReportIssues("shipping"), reg); err != nil {
+		t.Fatalf("RegisterMetrics of another app: %v", err)
+	}
+	if err := RegisterMetrics(ri, reg); err == nil {
+		t.Fatal("RegisterMetrics of the same app twice succeeded")
+	}
+	if !UnregisterMetrics(ri, reg) {
+		t.Fatal("UnregisterMetrics = false, want true")
+	}
+	if UnregisterMetrics(ri, reg) {
+		t.Fatal("second UnregisterMetrics = true, want false")
+	}
+}
@@ -0,0 +1,6 @@
+// Package proto holds the protobuf bindings of the Report wire format,
+// generated from report.proto, used by the "protobuf" Options.Encoding. See
+// issues.ReportToProto and issues.ProtoToReport for the conversions.
+package proto
+
+//go:generate protoc -I../.. --go_out=../.. --go_opt=paths=source_relative issues/proto/report.proto
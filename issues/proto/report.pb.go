@@ -0,0 +1,441 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: issues/proto/report.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Report mirrors issues.Report.
+type Report struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	V                 int32             `protobuf:"varint,1,opt,name=v,proto3" json:"v,omitempty"`
+	IssueId           uint32            `protobuf:"varint,2,opt,name=issue_id,json=issueId,proto3" json:"issue_id,omitempty"`
+	IssueId64         uint64            `protobuf:"varint,3,opt,name=issue_id64,json=issueId64,proto3" json:"issue_id64,omitempty"`
+	Meta              map[string]string `protobuf:"bytes,4,rep,name=meta,proto3" json:"meta,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Options           *structpb.Struct  `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`
+	Caller            string            `protobuf:"bytes,6,opt,name=caller,proto3" json:"caller,omitempty"`
+	StackTrace        []string          `protobuf:"bytes,7,rep,name=stack_trace,json=stackTrace,proto3" json:"stack_trace,omitempty"`
+	App               string            `protobuf:"bytes,8,opt,name=app,proto3" json:"app,omitempty"`
+	Extra             *structpb.Struct  `protobuf:"bytes,9,opt,name=extra,proto3" json:"extra,omitempty"`
+	Description       string            `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`
+	Level             string            `protobuf:"bytes,11,opt,name=level,proto3" json:"level,omitempty"`
+	Libversion        string            `protobuf:"bytes,12,opt,name=libversion,proto3" json:"libversion,omitempty"`
+	T                 int64             `protobuf:"varint,13,opt,name=t,proto3" json:"t,omitempty"`
+	SuppressedCount   int64             `protobuf:"varint,14,opt,name=suppressed_count,json=suppressedCount,proto3" json:"suppressed_count,omitempty"`
+	FirstSuppressedAt int64             `protobuf:"varint,15,opt,name=first_suppressed_at,json=firstSuppressedAt,proto3" json:"first_suppressed_at,omitempty"`
+	Tags              []string          `protobuf:"bytes,16,rep,name=tags,proto3" json:"tags,omitempty"`
+}
+
+func (x *Report) Reset() {
+	*x = Report{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_issues_proto_report_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Report) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Report) ProtoMessage() {}
+
+func (x *Report) ProtoReflect() protoreflect.Message {
+	mi := &file_issues_proto_report_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Report.ProtoReflect.Descriptor instead.
+func (*Report) Descriptor() ([]byte, []int) {
+	return file_issues_proto_report_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Report) GetV() int32 {
+	if x != nil {
+		return x.V
+	}
+	return 0
+}
+
+func (x *Report) GetIssueId() uint32 {
+	if x != nil {
+		return x.IssueId
+	}
+	return 0
+}
+
+func (x *Report) GetIssueId64() uint64 {
+	if x != nil {
+		return x.IssueId64
+	}
+	return 0
+}
+
+func (x *Report) GetMeta() map[string]string {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *Report) GetOptions() *structpb.Struct {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Report) GetCaller() string {
+	if x != nil {
+		return x.Caller
+	}
+	return ""
+}
+
+func (x *Report) GetStackTrace() []string {
+	if x != nil {
+		return x.StackTrace
+	}
+	return nil
+}
+
+func (x *Report) GetApp() string {
+	if x != nil {
+		return x.App
+	}
+	return ""
+}
+
+func (x *Report) GetExtra() *structpb.Struct {
+	if x != nil {
+		return x.Extra
+	}
+	return nil
+}
+
+func (x *Report) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Report) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *Report) GetLibversion() string {
+	if x != nil {
+		return x.Libversion
+	}
+	return ""
+}
+
+func (x *Report) GetT() int64 {
+	if x != nil {
+		return x.T
+	}
+	return 0
+}
+
+func (x *Report) GetSuppressedCount() int64 {
+	if x != nil {
+		return x.SuppressedCount
+	}
+	return 0
+}
+
+func (x *Report) GetFirstSuppressedAt() int64 {
+	if x != nil {
+		return x.FirstSuppressedAt
+	}
+	return 0
+}
+
+func (x *Report) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// ReportSubmission mirrors issues.ReportSubmission.
+type ReportSubmission struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Issue *Report `protobuf:"bytes,1,opt,name=issue,proto3" json:"issue,omitempty"`
+}
+
+func (x *ReportSubmission) Reset() {
+	*x = ReportSubmission{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_issues_proto_report_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportSubmission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportSubmission) ProtoMessage() {}
+
+func (x *ReportSubmission) ProtoReflect() protoreflect.Message {
+	mi := &file_issues_proto_report_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportSubmission.ProtoReflect.Descriptor instead.
+func (*ReportSubmission) Descriptor() ([]byte, []int) {
+	return file_issues_proto_report_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReportSubmission) GetIssue() *Report {
+	if x != nil {
+		return x.Issue
+	}
+	return nil
+}
+
+// BulkReportSubmission mirrors issues.BulkReportSubmission.
+type BulkReportSubmission struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Issues []*Report `protobuf:"bytes,1,rep,name=issues,proto3" json:"issues,omitempty"`
+}
+
+func (x *BulkReportSubmission) Reset() {
+	*x = BulkReportSubmission{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_issues_proto_report_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkReportSubmission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkReportSubmission) ProtoMessage() {}
+
+func (x *BulkReportSubmission) ProtoReflect() protoreflect.Message {
+	mi := &file_issues_proto_report_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkReportSubmission.ProtoReflect.Descriptor instead.
+func (*BulkReportSubmission) Descriptor() ([]byte, []int) {
+	return file_issues_proto_report_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BulkReportSubmission) GetIssues() []*Report {
+	if x != nil {
+		return x.Issues
+	}
+	return nil
+}
+
+var File_issues_proto_report_proto protoreflect.FileDescriptor
+
+var file_issues_proto_report_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x69, 0x73, 0x73, 0x75, 0x65, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11, 0x63, 0x6f, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x1a, 0x1c,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc4, 0x04, 0x0a,
+	0x06, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x76, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x01, 0x76, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x73, 0x75, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x69, 0x73, 0x73, 0x75, 0x65, 0x49, 0x64,
+	0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x73, 0x75, 0x65, 0x5f, 0x69, 0x64, 0x36, 0x34, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x69, 0x73, 0x73, 0x75, 0x65, 0x49, 0x64, 0x36, 0x34, 0x12,
+	0x37, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e,
+	0x63, 0x6f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x12, 0x31, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75,
+	0x63, 0x74, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x61, 0x6c, 0x6c, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61, 0x6c,
+	0x6c, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x5f, 0x74, 0x72, 0x61,
+	0x63, 0x65, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x54,
+	0x72, 0x61, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x70, 0x70, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x61, 0x70, 0x70, 0x12, 0x2d, 0x0a, 0x05, 0x65, 0x78, 0x74, 0x72, 0x61, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x05,
+	0x65, 0x78, 0x74, 0x72, 0x61, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x1e, 0x0a,
+	0x0a, 0x6c, 0x69, 0x62, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x6c, 0x69, 0x62, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0c, 0x0a,
+	0x01, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x01, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x73,
+	0x75, 0x70, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x73, 0x75, 0x70, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x13, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f,
+	0x73, 0x75, 0x70, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x11, 0x66, 0x69, 0x72, 0x73, 0x74, 0x53, 0x75, 0x70, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x65, 0x64, 0x41, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x10,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x1a, 0x37, 0x0a, 0x09, 0x4d, 0x65,
+	0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0x43, 0x0a, 0x10, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x75, 0x62,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2f, 0x0a, 0x05, 0x69, 0x73, 0x73, 0x75, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x6f, 0x61, 0x64, 0x6d, 0x69, 0x6e,
+	0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x52, 0x05, 0x69, 0x73, 0x73, 0x75, 0x65, 0x22, 0x49, 0x0a, 0x14, 0x42, 0x75, 0x6c, 0x6b,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x31, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x63, 0x6f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e, 0x72, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x06, 0x69, 0x73, 0x73,
+	0x75, 0x65, 0x73, 0x42, 0x2a, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x37, 0x63, 0x2f, 0x63, 0x6f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2d, 0x67, 0x6f, 0x6c,
+	0x69, 0x62, 0x2f, 0x69, 0x73, 0x73, 0x75, 0x65, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_issues_proto_report_proto_rawDescOnce sync.Once
+	file_issues_proto_report_proto_rawDescData = file_issues_proto_report_proto_rawDesc
+)
+
+func file_issues_proto_report_proto_rawDescGZIP() []byte {
+	file_issues_proto_report_proto_rawDescOnce.Do(func() {
+		file_issues_proto_report_proto_rawDescData = protoimpl.X.CompressGZIP(file_issues_proto_report_proto_rawDescData)
+	})
+	return file_issues_proto_report_proto_rawDescData
+}
+
+var file_issues_proto_report_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_issues_proto_report_proto_goTypes = []any{
+	(*Report)(nil),               // 0: coadmin.report.v1.Report
+	(*ReportSubmission)(nil),     // 1: coadmin.report.v1.ReportSubmission
+	(*BulkReportSubmission)(nil), // 2: coadmin.report.v1.BulkReportSubmission
+	nil,                          // 3: coadmin.report.v1.Report.MetaEntry
+	(*structpb.Struct)(nil),      // 4: google.protobuf.Struct
+}
+var file_issues_proto_report_proto_depIdxs = []int32{
+	3, // 0: coadmin.report.v1.Report.meta:type_name -> coadmin.report.v1.Report.MetaEntry
+	4, // 1: coadmin.report.v1.Report.options:type_name -> google.protobuf.Struct
+	4, // 2: coadmin.report.v1.Report.extra:type_name -> google.protobuf.Struct
+	0, // 3: coadmin.report.v1.ReportSubmission.issue:type_name -> coadmin.report.v1.Report
+	0, // 4: coadmin.report.v1.BulkReportSubmission.issues:type_name -> coadmin.report.v1.Report
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_issues_proto_report_proto_init() }
+func file_issues_proto_report_proto_init() {
+	if File_issues_proto_report_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_issues_proto_report_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Report); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_issues_proto_report_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ReportSubmission); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_issues_proto_report_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*BulkReportSubmission); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_issues_proto_report_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_issues_proto_report_proto_goTypes,
+		DependencyIndexes: file_issues_proto_report_proto_depIdxs,
+		MessageInfos:      file_issues_proto_report_proto_msgTypes,
+	}.Build()
+	File_issues_proto_report_proto = out.File
+	file_issues_proto_report_proto_rawDesc = nil
+	file_issues_proto_report_proto_goTypes = nil
+	file_issues_proto_report_proto_depIdxs = nil
+}